@@ -0,0 +1,66 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Blocked",type="string",JSONPath=`.status.conditions[?(@.type=="Blocked")].status`
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.statusMessage",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterCRDCleanupPolicy is the Schema for the clustercrdcleanuppolicies API.
+// It is the cluster-scoped counterpart of CRDCleanupPolicy and takes precedence over it:
+// CRDs listed in a ClusterCRDCleanupPolicy are not processed by any namespaced CRDCleanupPolicy.
+type ClusterCRDCleanupPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CRDCleanupPolicySpec   `json:"spec,omitempty"`
+	Status CRDCleanupPolicyStatus `json:"status,omitempty"`
+}
+
+// GetSpec returns the spec of the policy.
+func (p *ClusterCRDCleanupPolicy) GetSpec() *CRDCleanupPolicySpec {
+	return &p.Spec
+}
+
+// GetStatus returns the status of the policy.
+func (p *ClusterCRDCleanupPolicy) GetStatus() *CRDCleanupPolicyStatus {
+	return &p.Status
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterCRDCleanupPolicyList contains a list of ClusterCRDCleanupPolicy.
+type ClusterCRDCleanupPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterCRDCleanupPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterCRDCleanupPolicy{}, &ClusterCRDCleanupPolicyList{})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CRDCleanupGrantSpec defines which namespaces may target which CRDs with a CRDCleanupPolicy.
+type CRDCleanupGrantSpec struct {
+	// Namespaces is a list of namespaces whose CRDCleanupPolicies are granted access.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// NamespaceSelector selects the namespaces whose CRDCleanupPolicies are granted access by their labels.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Groups is a list of API groups of the CRDs the namespaces may target. Glob patterns such as
+	// "*.example.com" are supported.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+
+	// Names is a list of names of the CRDs the namespaces may target. Glob patterns such as
+	// "*.example.com" are supported.
+	// +optional
+	Names []string `json:"names,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CRDCleanupGrant is the Schema for the crdcleanupgrants API.
+// It allows CRDCleanupPolicies in the selected namespaces to delete the selected CRDs and versions.
+// Entries of namespaced policies that are not granted by any CRDCleanupGrant are denied.
+type CRDCleanupGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CRDCleanupGrantSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CRDCleanupGrantList contains a list of CRDCleanupGrant.
+type CRDCleanupGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CRDCleanupGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CRDCleanupGrant{}, &CRDCleanupGrantList{})
+}
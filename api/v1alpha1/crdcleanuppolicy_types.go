@@ -26,32 +26,520 @@ type CRDCleanupVersion struct {
 
 	// Version is the apiVersion of the CustomResourceDefinition that the operator should delete.
 	Version string `json:"version,omitempty"`
+
+	// StorageSuccessor is the version that becomes the storage version if Version is the current storage version
+	// of the CRD. The stored objects are migrated to it before Version is removed. If empty, the served version
+	// with the highest priority is chosen.
+	// +optional
+	StorageSuccessor string `json:"storageSuccessor,omitempty"`
+
+	// AcknowledgeProtected allows the entry to target a CRD on the protected list of the operator.
+	// Without it, entries for protected CRDs are denied.
+	// +optional
+	AcknowledgeProtected *ProtectionAcknowledgement `json:"acknowledgeProtected,omitempty"`
+
+	// InstancePolicy overrides the instance policy of the policy for this entry.
+	// +optional
+	InstancePolicy InstancePolicy `json:"instancePolicy,omitempty"`
+}
+
+// InstancePolicy defines how the operator handles the instances of a CRD that should be deleted.
+// +kubebuilder:validation:Enum=Block;Delete;BackupThenDelete
+type InstancePolicy string
+
+const (
+	// InstancePolicyBlock keeps the CRD as long as instances of it exist.
+	InstancePolicyBlock InstancePolicy = "Block"
+
+	// InstancePolicyDelete deletes the instances and removes the CRD once they are gone.
+	InstancePolicyDelete InstancePolicy = "Delete"
+
+	// InstancePolicyBackupThenDelete backs up the instances before they are deleted.
+	InstancePolicyBackupThenDelete InstancePolicy = "BackupThenDelete"
+)
+
+// ProtectionAcknowledgement explicitly acknowledges the removal of a protected CRD.
+type ProtectionAcknowledgement struct {
+	// Reason explains why the protected CRD has to be removed. It is recorded in the status of the entry.
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+}
+
+// CRDSelector selects CustomResourceDefinitions. A CRD is selected if it matches all fields that are set.
+type CRDSelector struct {
+	// LabelSelector selects CRDs by the labels of the CustomResourceDefinition object.
+	// +optional
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+
+	// Group is the API group of the CRDs, either exact or a glob pattern such as "*.example.com".
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Categories selects CRDs that are in all of the given categories, as listed in spec.names.categories.
+	// +optional
+	Categories []string `json:"categories,omitempty"`
+
+	// Names are glob patterns of CRD names. A CRD is selected if its name matches any of them.
+	// +optional
+	Names []string `json:"names,omitempty"`
+}
+
+// DeprecatedVersionsTarget targets the versions of CustomResourceDefinitions that are marked as deprecated.
+type DeprecatedVersionsTarget struct {
+	// Selector restricts the CRDs whose deprecated versions are targeted. If unset, the deprecated versions
+	// of all CRDs are targeted.
+	// +optional
+	Selector *CRDSelector `json:"selector,omitempty"`
+}
+
+// UnusedCRDCollection deletes CRDs only after they had no instances for a quiet period.
+type UnusedCRDCollection struct {
+	// QuietPeriod is how long a CRD must have had no instances before it is deleted, e.g. 720h for 30 days.
+	QuietPeriod metav1.Duration `json:"quietPeriod"`
+
+	// Selector selects CRDs to collect in addition to the entries of the policy. Like Selectors, it is
+	// expanded into entries on every reconciliation.
+	// +optional
+	Selector *CRDSelector `json:"selector,omitempty"`
+}
+
+// MaintenanceSchedule restricts the times a policy may change the cluster. Entries are evaluated at any time,
+// but CRDs, versions and instances are only deleted or updated within the schedule.
+type MaintenanceSchedule struct {
+	// Windows are the maintenance windows changes are made in. If empty, changes are made at any time
+	// between NotBefore and NotAfter.
+	// +optional
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+
+	// TimeZone is the IANA name of the time zone the windows are evaluated in, e.g. "Europe/Berlin".
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// NotBefore is the time before which no changes are made.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// NotAfter is the time after which no changes are made anymore.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period in which changes are allowed.
+type MaintenanceWindow struct {
+	// Cron is a cron expression with the five fields minute, hour, day of month, month and day of week,
+	// which defines when the window opens, e.g. "0 22 * * 1-5" for 22:00 on weekdays.
+	// +kubebuilder:validation:MinLength=1
+	Cron string `json:"cron"`
+
+	// Duration is how long the window stays open, e.g. 2h.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// CleanupMode defines whether a policy deletes CRDs or only plans their deletion.
+// +kubebuilder:validation:Enum=DryRun;Enforce
+type CleanupMode string
+
+const (
+	// CleanupModeDryRun evaluates every entry and records a plan without deleting anything.
+	CleanupModeDryRun CleanupMode = "DryRun"
+
+	// CleanupModeEnforce deletes the CRDs and versions listed in the policy.
+	CleanupModeEnforce CleanupMode = "Enforce"
+)
+
+// VersionLifecycle configures the stages a version passes before it is removed from a CRD.
+// A version is first marked as deprecated, then no longer served and finally removed.
+type VersionLifecycle struct {
+	// DeprecationPeriod is how long a version stays deprecated before it is no longer served.
+	// +optional
+	DeprecationPeriod *metav1.Duration `json:"deprecationPeriod,omitempty"`
+
+	// UnservedPeriod is how long a version is no longer served before it is removed from the CRD.
+	// +optional
+	UnservedPeriod *metav1.Duration `json:"unservedPeriod,omitempty"`
+
+	// DeprecationWarning is the warning returned to API clients using a deprecated version.
+	// If empty, the API server returns a default warning.
+	// +optional
+	DeprecationWarning string `json:"deprecationWarning,omitempty"`
 }
 
 // CRDCleanupPolicySpec defines the desired state of CRDCleanupPolicy.
 type CRDCleanupPolicySpec struct {
+	// Mode controls whether the operator deletes the listed CRDs (Enforce) or only records
+	// what it would delete (DryRun). In DryRun mode all delete and update calls are sent
+	// with dryRun=All, so admission and RBAC are exercised without persisting any change,
+	// and the entries that would be deleted are reported in the Planned phase.
+	// +kubebuilder:default=Enforce
+	// +optional
+	Mode CleanupMode `json:"mode,omitempty"`
+
+	// Suspend stops all deletions and updates the operator makes on behalf of the policy, including dry-run
+	// calls. Entries are still evaluated and reported in the Suspended phase until the policy is resumed.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
 	// CRDsVersions is a list of names and apiVersions of CustomResourceDefinitions that the operator should delete.
 	// Only the name of the CRD is required.
 	CRDsVersions []CRDCleanupVersion `json:"crdsversions,omitempty"`
+
+	// Selectors select CustomResourceDefinitions the operator should delete in addition to CRDsVersions.
+	// They are expanded into entries on every reconciliation, so CRDs created later are selected as well.
+	// +optional
+	Selectors []CRDSelector `json:"selectors,omitempty"`
+
+	// DeprecatedVersions targets every version of a CustomResourceDefinition that is marked as deprecated in
+	// spec.versions. Like Selectors, it is expanded into entries on every reconciliation.
+	// +optional
+	DeprecatedVersions *DeprecatedVersionsTarget `json:"deprecatedVersions,omitempty"`
+
+	// UnusedCRDs garbage collects CRDs that had no instances for a quiet period. If set, every entry that targets
+	// a whole CRD is only deleted once the CRD had no instances for the quiet period.
+	// +optional
+	UnusedCRDs *UnusedCRDCollection `json:"unusedCRDs,omitempty"`
+
+	// InstancePolicy defines how instances of a CRD that should be deleted are handled: Block (default) keeps
+	// the CRD until all instances are gone, Delete deletes the instances first and BackupThenDelete backs them
+	// up before deleting them. Entries that only remove a version are not affected, since the objects are
+	// migrated to the remaining versions.
+	// +kubebuilder:default=Block
+	// +optional
+	InstancePolicy InstancePolicy `json:"instancePolicy,omitempty"`
+
+	// VersionLifecycle stages the removal of versions listed in CRDsVersions. If set, a version is deprecated
+	// and unserved for the configured periods before it is removed. If unset, versions are removed right away.
+	// Entries without a version are not affected.
+	// +optional
+	VersionLifecycle *VersionLifecycle `json:"versionLifecycle,omitempty"`
+
+	// Schedule restricts deletions and updates to maintenance windows. If unset, changes are made at any time.
+	// +optional
+	Schedule *MaintenanceSchedule `json:"schedule,omitempty"`
 }
 
+// CRDCleanupPhase describes where a single entry of a CRDCleanupPolicy is in the cleanup process.
+// +kubebuilder:validation:Enum=Pending;Planned;Blocked;Unused;Draining;Deprecated;Unserved;Migrating;Deleting;Deleted;NotFound;Failed;Superseded;Denied;Scheduled;Suspended
+type CRDCleanupPhase string
+
+const (
+	// CRDCleanupPhasePending means the entry has not been evaluated yet.
+	CRDCleanupPhasePending CRDCleanupPhase = "Pending"
+
+	// CRDCleanupPhasePlanned means the entry would be deleted if the policy was enforced.
+	CRDCleanupPhasePlanned CRDCleanupPhase = "Planned"
+
+	// CRDCleanupPhaseBlocked means the entry cannot be deleted yet, e.g. because instances still exist
+	// or the version that should be removed is the last version of the CRD.
+	CRDCleanupPhaseBlocked CRDCleanupPhase = "Blocked"
+
+	// CRDCleanupPhaseUnused means the CRD has no instances and waits for the quiet period to end before it is deleted.
+	CRDCleanupPhaseUnused CRDCleanupPhase = "Unused"
+
+	// CRDCleanupPhaseDraining means the instances of the CRD are being deleted before the CRD is deleted.
+	CRDCleanupPhaseDraining CRDCleanupPhase = "Draining"
+
+	// CRDCleanupPhaseDeprecated means the version has been marked as deprecated and waits to be no longer served.
+	CRDCleanupPhaseDeprecated CRDCleanupPhase = "Deprecated"
+
+	// CRDCleanupPhaseUnserved means the version is no longer served and waits to be removed.
+	CRDCleanupPhaseUnserved CRDCleanupPhase = "Unserved"
+
+	// CRDCleanupPhaseMigrating means the stored objects are being migrated away from the version that should be removed.
+	CRDCleanupPhaseMigrating CRDCleanupPhase = "Migrating"
+
+	// CRDCleanupPhaseDeleting means the deletion was requested and the CRD is terminating.
+	CRDCleanupPhaseDeleting CRDCleanupPhase = "Deleting"
+
+	// CRDCleanupPhaseDeleted means the CRD or version was removed by the operator.
+	CRDCleanupPhaseDeleted CRDCleanupPhase = "Deleted"
+
+	// CRDCleanupPhaseNotFound means the CRD or version did not exist while processing.
+	CRDCleanupPhaseNotFound CRDCleanupPhase = "NotFound"
+
+	// CRDCleanupPhaseFailed means the last attempt to process the entry failed. It is retried.
+	CRDCleanupPhaseFailed CRDCleanupPhase = "Failed"
+
+	// CRDCleanupPhaseSuperseded means the CRD is listed in a ClusterCRDCleanupPolicy, which takes precedence
+	// over namespaced policies. The entry is not processed as long as a cluster policy lists the CRD.
+	CRDCleanupPhaseSuperseded CRDCleanupPhase = "Superseded"
+
+	// CRDCleanupPhaseDenied means the policy is not allowed to target the CRD, either because no CRDCleanupGrant
+	// allows the namespace of the policy or because the user that requested the policy may not delete the CRD.
+	// The entry is processed as soon as it is allowed.
+	CRDCleanupPhaseDenied CRDCleanupPhase = "Denied"
+
+	// CRDCleanupPhaseScheduled means the entry has been evaluated, but the next change is outside of the schedule
+	// of the policy. It is made once the next maintenance window opens.
+	CRDCleanupPhaseScheduled CRDCleanupPhase = "Scheduled"
+
+	// CRDCleanupPhaseSuspended means the entry has been evaluated, but the next change is deferred because the
+	// policy is suspended or the operator is stopped. It is made once both are resumed.
+	CRDCleanupPhaseSuspended CRDCleanupPhase = "Suspended"
+)
+
+// CRDMigrationStatus describes the migration of the stored objects of a CRD to its storage version,
+// which is required before a version that objects are still stored in can be removed.
+type CRDMigrationStatus struct {
+	// StorageVersion is the version the objects are migrated to.
+	StorageVersion string `json:"storageVersion"`
+
+	// PreviousStorageVersion is the storage version of the CRD before it was handed over to StorageVersion.
+	// Empty if the removed version was not the storage version.
+	// +optional
+	PreviousStorageVersion string `json:"previousStorageVersion,omitempty"`
+
+	// StorageVersionMigration is the name of the storagemigration.k8s.io StorageVersionMigration that migrates
+	// the objects. Empty if the objects are migrated by the operator itself.
+	// +optional
+	StorageVersionMigration string `json:"storageVersionMigration,omitempty"`
+
+	// HandOverTime is the time the storage version was handed over from PreviousStorageVersion to StorageVersion.
+	// The objects are only migrated once the API servers had time to pick up the new storage version.
+	// +optional
+	HandOverTime *metav1.Time `json:"handOverTime,omitempty"`
+
+	// MigratedObjects is the number of objects rewritten in the storage version during the last attempt.
+	// It is not reported for migrations performed by a StorageVersionMigration.
+	// +optional
+	MigratedObjects int32 `json:"migratedObjects,omitempty"`
+
+	// FailedObjects is the number of objects that could not be rewritten during the last attempt.
+	// +optional
+	FailedObjects int32 `json:"failedObjects,omitempty"`
+
+	// StartTime is the time the last migration attempt started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the version was removed from the stored versions of the CRD.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// CRDVersionLifecycleStatus records when a version entered the stages of its lifecycle.
+type CRDVersionLifecycleStatus struct {
+	// DeprecatedTime is the time the version was marked as deprecated.
+	// +optional
+	DeprecatedTime *metav1.Time `json:"deprecatedTime,omitempty"`
+
+	// UnservedTime is the time the version stopped being served.
+	// +optional
+	UnservedTime *metav1.Time `json:"unservedTime,omitempty"`
+
+	// RemovedTime is the time the version was removed from the CRD.
+	// +optional
+	RemovedTime *metav1.Time `json:"removedTime,omitempty"`
+}
+
+// CRDCleanupEntryStatus is the observed state of a single CRD or CRD version listed in a policy.
+type CRDCleanupEntryStatus struct {
+	// Name is the name of the CustomResourceDefinition.
+	Name string `json:"name"`
+
+	// Version is the apiVersion of the CustomResourceDefinition. Empty if the whole CRD is targeted.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Selected is true if the entry was expanded from the selectors or deprecated versions of the policy.
+	// +optional
+	Selected bool `json:"selected,omitempty"`
+
+	// Phase is the current phase of the entry.
+	Phase CRDCleanupPhase `json:"phase"`
+
+	// Message is a human readable explanation of the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// InstanceCount is the number of instances of the CRD observed during the last evaluation.
+	// +optional
+	InstanceCount int32 `json:"instanceCount,omitempty"`
+
+	// BlockedVersion is the version of the CRD the entry is blocked on, e.g. because it is
+	// the last version of the CRD. Empty if the entry is not blocked by a version.
+	// +optional
+	BlockedVersion string `json:"blockedVersion,omitempty"`
+
+	// DeletedInstances is the number of instances of the CRD the operator deleted.
+	// +optional
+	DeletedInstances int32 `json:"deletedInstances,omitempty"`
+
+	// Backup references the backup of the CRD and its instances taken before the CRD or version was removed.
+	// +optional
+	Backup *CRDBackupStatus `json:"backup,omitempty"`
+
+	// UnusedSince is the time since which the CRD has been observed without instances. It is only tracked
+	// if the policy collects unused CRDs.
+	// +optional
+	UnusedSince *metav1.Time `json:"unusedSince,omitempty"`
+
+	// Lifecycle records the stages of the version if the policy configures a version lifecycle.
+	// +optional
+	Lifecycle *CRDVersionLifecycleStatus `json:"lifecycle,omitempty"`
+
+	// Migration is the progress of migrating stored objects away from the version before it is removed.
+	// +optional
+	Migration *CRDMigrationStatus `json:"migration,omitempty"`
+
+	// Protection records the acknowledgement if the entry targets a protected CRD.
+	// +optional
+	Protection *CRDProtectionStatus `json:"protection,omitempty"`
+
+	// LastError is the error of the last failed attempt to process the entry.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Attempts is the number of times the operator tried to delete the CRD or version.
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// LastTransitionTime is the last time the phase of the entry changed.
+	// +optional
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// BackupSinkType is the kind of storage a backup is written to.
+// +kubebuilder:validation:Enum=Directory;ConfigMap;Secret
+type BackupSinkType string
+
+const (
+	// BackupSinkDirectory writes backups as files to a directory, e.g. on a mounted PersistentVolumeClaim.
+	BackupSinkDirectory BackupSinkType = "Directory"
+
+	// BackupSinkConfigMap writes backups as chunks to ConfigMaps.
+	BackupSinkConfigMap BackupSinkType = "ConfigMap"
+
+	// BackupSinkSecret writes backups as chunks to Secrets.
+	BackupSinkSecret BackupSinkType = "Secret"
+)
+
+// BackupReference locates a backup of a CRD and its instances.
+type BackupReference struct {
+	// Sink is the kind of storage the backup was written to.
+	Sink BackupSinkType `json:"sink"`
+
+	// Location is the path of the backup file or the name of the first ConfigMap or Secret holding the backup.
+	Location string `json:"location"`
+
+	// Namespace is the namespace of the ConfigMaps or Secrets holding the backup.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// KeyID is the ID of the key the backup is encrypted with. Empty if the backup is not encrypted.
+	// The ID is also stored in the backup itself, which is what a restore relies on.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+}
+
+// CRDBackupStatus records the backup of a CRD and its instances.
+type CRDBackupStatus struct {
+	BackupReference `json:",inline"`
+
+	// Instances is the number of instances contained in the backup.
+	Instances int32 `json:"instances"`
+
+	// Time is the time the backup was taken.
+	Time metav1.Time `json:"time"`
+}
+
+// CRDProtectionStatus records who acknowledged the removal of a protected CRD and why.
+type CRDProtectionStatus struct {
+	// Pattern is the pattern of the protected list that matches the CRD.
+	Pattern string `json:"pattern"`
+
+	// Reason is the reason given in the acknowledgement.
+	Reason string `json:"reason"`
+
+	// AcknowledgedBy is the user that requested the policy when the acknowledgement was first processed.
+	// +optional
+	AcknowledgedBy string `json:"acknowledgedBy,omitempty"`
+
+	// AcknowledgedTime is the time the acknowledgement was first processed.
+	AcknowledgedTime metav1.Time `json:"acknowledgedTime"`
+}
+
+// Annotations recorded on CRDCleanupPolicies and ClusterCRDCleanupPolicies by the admission webhook.
+const (
+	// RequestedByAnnotation is the name of the user that created or last updated the policy.
+	RequestedByAnnotation = "policies.kreepy.kubecrew.de/requested-by"
+
+	// RequestedByGroupsAnnotation is the comma separated list of groups of the user that created or last updated the policy.
+	RequestedByGroupsAnnotation = "policies.kreepy.kubecrew.de/requested-by-groups"
+)
+
+// InstanceEditorLabel marks ClusterRoles that are aggregated into the role the operator uses to delete, migrate and
+// restore the instances of CRDs if its value is "true".
+const InstanceEditorLabel = "policies.kreepy.kubecrew.de/aggregate-to-instance-editor"
+
+// Condition types reported on a CRDCleanupPolicy.
+const (
+	// ConditionTypeReady is True once every entry of the policy has been processed.
+	// In DryRun mode it is True once the plan has been computed for every entry.
+	ConditionTypeReady = "Ready"
+
+	// ConditionTypeProgressing is True while the operator is deleting CRDs or versions.
+	ConditionTypeProgressing = "Progressing"
+
+	// ConditionTypeBlocked is True if at least one entry cannot be deleted yet.
+	// The entries report the reason in their message and the version they are blocked on.
+	ConditionTypeBlocked = "Blocked"
+
+	// ConditionTypeDegraded is True if processing at least one entry failed.
+	ConditionTypeDegraded = "Degraded"
+
+	// ConditionTypeInMaintenanceWindow is True while the schedule of the policy allows changes.
+	// Policies without a schedule are always in a maintenance window.
+	ConditionTypeInMaintenanceWindow = "InMaintenanceWindow"
+
+	// ConditionTypeSuspended is True while the policy is suspended or the operator is stopped by its emergency
+	// stop. The reason tells which of them applies.
+	ConditionTypeSuspended = "Suspended"
+)
+
 // CRDCleanupPolicyStatus defines the observed state of CRDCleanupPolicy.
 type CRDCleanupPolicyStatus struct {
 	// StatusMessage provides information about the current state of the cleanup process.
 	StatusMessage string `json:"statusMessage,omitempty"`
 
-	// ProcessedCRDs is a list of names of CRDs that have already been processed by the operator.
-	ProcessedCRDs []string `json:"processedCrds"`
+	// ObservedGeneration is the most recent generation of the policy observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Progress is the number of processed entries out of all entries, e.g. "2/3".
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Conditions represent the latest available observations of the policy's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// NextMaintenanceWindow is the time the next maintenance window of the schedule opens. Empty while a window
+	// is open or if no window opens anymore.
+	// +optional
+	NextMaintenanceWindow *metav1.Time `json:"nextMaintenanceWindow,omitempty"`
 
-	// RemainingCRDs is a list of names of CRDs that are yet to be processed.
-	RemainingCRDs []string `json:"remainingCrds"`
+	// SelectedCRDs are the CRDs and CRD versions the selectors and deprecated versions of the policy expanded to
+	// during the last reconciliation, in the form "name" or "name/version".
+	// +optional
+	SelectedCRDs []string `json:"selectedCRDs,omitempty"`
 
-	// NonExistentCRDs is a list of names of CRDs that were not existing while processing.
-	NonExistentCRDs []string `json:"nonExistentCrds"`
+	// Entries is the status of every CRD and CRD version listed in the policy.
+	// +optional
+	Entries []CRDCleanupEntryStatus `json:"entries,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Mode",type="string",JSONPath=".spec.mode"
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`
+// +kubebuilder:printcolumn:name="Blocked",type="string",JSONPath=`.status.conditions[?(@.type=="Blocked")].status`
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.statusMessage",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // CRDCleanupPolicy is the Schema for the crdcleanuppolicies API.
 type CRDCleanupPolicy struct {
@@ -62,6 +550,16 @@ type CRDCleanupPolicy struct {
 	Status CRDCleanupPolicyStatus `json:"status,omitempty"`
 }
 
+// GetSpec returns the spec of the policy.
+func (p *CRDCleanupPolicy) GetSpec() *CRDCleanupPolicySpec {
+	return &p.Spec
+}
+
+// GetStatus returns the status of the policy.
+func (p *CRDCleanupPolicy) GetStatus() *CRDCleanupPolicyStatus {
+	return &p.Status
+}
+
 // +kubebuilder:object:root=true
 
 // CRDCleanupPolicyList contains a list of CRDCleanupPolicy.
@@ -0,0 +1,162 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CRDRestoreSpec defines the backup a CRDRestore restores.
+type CRDRestoreSpec struct {
+	// Backup references the backup to restore, as recorded in the backup of an entry of a
+	// CRDCleanupPolicy or ClusterCRDCleanupPolicy.
+	Backup BackupReference `json:"backup"`
+}
+
+// CRDRestorePhase is the phase of a CRDRestore.
+// +kubebuilder:validation:Enum=Pending;Establishing;Restoring;Completed;Failed
+type CRDRestorePhase string
+
+const (
+	// CRDRestorePhasePending means the restore has not been processed yet or waits for the emergency stop of the
+	// operator to be released.
+	CRDRestorePhasePending CRDRestorePhase = "Pending"
+
+	// CRDRestorePhaseEstablishing means the CRD has been created, but is not Established yet.
+	CRDRestorePhaseEstablishing CRDRestorePhase = "Establishing"
+
+	// CRDRestorePhaseRestoring means the instances of the CRD are being created.
+	CRDRestorePhaseRestoring CRDRestorePhase = "Restoring"
+
+	// CRDRestorePhaseCompleted means the CRD and all of its instances have been restored.
+	CRDRestorePhaseCompleted CRDRestorePhase = "Completed"
+
+	// CRDRestorePhaseFailed means the backup could not be read or at least one object could not be restored.
+	// Failed objects are retried periodically.
+	CRDRestorePhaseFailed CRDRestorePhase = "Failed"
+)
+
+// RestoredObjectPhase is the result of restoring a single object.
+// +kubebuilder:validation:Enum=Created;Updated;Exists;Failed
+type RestoredObjectPhase string
+
+const (
+	// RestoredObjectPhaseCreated means the object was created from the backup.
+	RestoredObjectPhaseCreated RestoredObjectPhase = "Created"
+
+	// RestoredObjectPhaseUpdated means the object already existed and the versions of the backup were added to it.
+	// It is only reported for the CRD.
+	RestoredObjectPhaseUpdated RestoredObjectPhase = "Updated"
+
+	// RestoredObjectPhaseExists means the object already existed and was left unchanged.
+	RestoredObjectPhaseExists RestoredObjectPhase = "Exists"
+
+	// RestoredObjectPhaseFailed means the object could not be restored.
+	RestoredObjectPhaseFailed RestoredObjectPhase = "Failed"
+)
+
+// RestoredObjectStatus is the result of restoring a single object of the backup.
+type RestoredObjectStatus struct {
+	// APIVersion is the apiVersion of the object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind is the kind of the object.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the object. Empty for cluster-scoped objects.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the name of the object.
+	Name string `json:"name"`
+
+	// Phase is the result of restoring the object.
+	Phase RestoredObjectPhase `json:"phase"`
+
+	// Message is the error if the object could not be restored.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CRDRestoreStatus defines the observed state of CRDRestore.
+type CRDRestoreStatus struct {
+	// Phase is the current phase of the restore.
+	// +optional
+	Phase CRDRestorePhase `json:"phase,omitempty"`
+
+	// Message is a human readable explanation of the current phase.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the most recent generation of the restore observed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// CRD is the name of the CustomResourceDefinition contained in the backup.
+	// +optional
+	CRD string `json:"crd,omitempty"`
+
+	// Progress is the number of restored objects out of all objects of the backup, e.g. "2/3".
+	// +optional
+	Progress string `json:"progress,omitempty"`
+
+	// Objects is the result of restoring the CRD and each of its instances, in the order they were restored.
+	// +optional
+	Objects []RestoredObjectStatus `json:"objects,omitempty"`
+
+	// CompletionTime is the time all objects of the backup were restored.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Conditions represent the latest available observations of the restore's state.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="CRD",type="string",JSONPath=".status.crd"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress"
+// +kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.message",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// CRDRestore is the Schema for the crdrestores API.
+// It re-creates a CRD and its instances from a backup taken before a CRDCleanupPolicy removed them.
+type CRDRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CRDRestoreSpec   `json:"spec,omitempty"`
+	Status CRDRestoreStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CRDRestoreList contains a list of CRDRestore.
+type CRDRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CRDRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CRDRestore{}, &CRDRestoreList{})
+}
@@ -21,9 +21,174 @@ limitations under the License.
 package v1alpha1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupReference) DeepCopyInto(out *BackupReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupReference.
+func (in *BackupReference) DeepCopy() *BackupReference {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDBackupStatus) DeepCopyInto(out *CRDBackupStatus) {
+	*out = *in
+	out.BackupReference = in.BackupReference
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDBackupStatus.
+func (in *CRDBackupStatus) DeepCopy() *CRDBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDCleanupEntryStatus) DeepCopyInto(out *CRDCleanupEntryStatus) {
+	*out = *in
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(CRDBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnusedSince != nil {
+		in, out := &in.UnusedSince, &out.UnusedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(CRDVersionLifecycleStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migration != nil {
+		in, out := &in.Migration, &out.Migration
+		*out = new(CRDMigrationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Protection != nil {
+		in, out := &in.Protection, &out.Protection
+		*out = new(CRDProtectionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDCleanupEntryStatus.
+func (in *CRDCleanupEntryStatus) DeepCopy() *CRDCleanupEntryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDCleanupEntryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDCleanupGrant) DeepCopyInto(out *CRDCleanupGrant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDCleanupGrant.
+func (in *CRDCleanupGrant) DeepCopy() *CRDCleanupGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDCleanupGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CRDCleanupGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDCleanupGrantList) DeepCopyInto(out *CRDCleanupGrantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CRDCleanupGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDCleanupGrantList.
+func (in *CRDCleanupGrantList) DeepCopy() *CRDCleanupGrantList {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDCleanupGrantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CRDCleanupGrantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDCleanupGrantSpec) DeepCopyInto(out *CRDCleanupGrantSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = (*in).DeepCopy()
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDCleanupGrantSpec.
+func (in *CRDCleanupGrantSpec) DeepCopy() *CRDCleanupGrantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDCleanupGrantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CRDCleanupPolicy) DeepCopyInto(out *CRDCleanupPolicy) {
 	*out = *in
@@ -89,7 +254,36 @@ func (in *CRDCleanupPolicySpec) DeepCopyInto(out *CRDCleanupPolicySpec) {
 	if in.CRDsVersions != nil {
 		in, out := &in.CRDsVersions, &out.CRDsVersions
 		*out = make([]CRDCleanupVersion, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]CRDSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DeprecatedVersions != nil {
+		in, out := &in.DeprecatedVersions, &out.DeprecatedVersions
+		*out = new(DeprecatedVersionsTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UnusedCRDs != nil {
+		in, out := &in.UnusedCRDs, &out.UnusedCRDs
+		*out = new(UnusedCRDCollection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VersionLifecycle != nil {
+		in, out := &in.VersionLifecycle, &out.VersionLifecycle
+		*out = new(VersionLifecycle)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(MaintenanceSchedule)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -106,21 +300,29 @@ func (in *CRDCleanupPolicySpec) DeepCopy() *CRDCleanupPolicySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CRDCleanupPolicyStatus) DeepCopyInto(out *CRDCleanupPolicyStatus) {
 	*out = *in
-	if in.ProcessedCRDs != nil {
-		in, out := &in.ProcessedCRDs, &out.ProcessedCRDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.RemainingCRDs != nil {
-		in, out := &in.RemainingCRDs, &out.RemainingCRDs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.NextMaintenanceWindow != nil {
+		in, out := &in.NextMaintenanceWindow, &out.NextMaintenanceWindow
+		*out = (*in).DeepCopy()
 	}
-	if in.NonExistentCRDs != nil {
-		in, out := &in.NonExistentCRDs, &out.NonExistentCRDs
+	if in.SelectedCRDs != nil {
+		in, out := &in.SelectedCRDs, &out.SelectedCRDs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]CRDCleanupEntryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDCleanupPolicyStatus.
@@ -136,6 +338,11 @@ func (in *CRDCleanupPolicyStatus) DeepCopy() *CRDCleanupPolicyStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CRDCleanupVersion) DeepCopyInto(out *CRDCleanupVersion) {
 	*out = *in
+	if in.AcknowledgeProtected != nil {
+		in, out := &in.AcknowledgeProtected, &out.AcknowledgeProtected
+		*out = new(ProtectionAcknowledgement)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDCleanupVersion.
@@ -147,3 +354,407 @@ func (in *CRDCleanupVersion) DeepCopy() *CRDCleanupVersion {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDMigrationStatus) DeepCopyInto(out *CRDMigrationStatus) {
+	*out = *in
+	if in.HandOverTime != nil {
+		in, out := &in.HandOverTime, &out.HandOverTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDMigrationStatus.
+func (in *CRDMigrationStatus) DeepCopy() *CRDMigrationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDMigrationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDProtectionStatus) DeepCopyInto(out *CRDProtectionStatus) {
+	*out = *in
+	in.AcknowledgedTime.DeepCopyInto(&out.AcknowledgedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDProtectionStatus.
+func (in *CRDProtectionStatus) DeepCopy() *CRDProtectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDProtectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDRestore) DeepCopyInto(out *CRDRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDRestore.
+func (in *CRDRestore) DeepCopy() *CRDRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CRDRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDRestoreList) DeepCopyInto(out *CRDRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CRDRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDRestoreList.
+func (in *CRDRestoreList) DeepCopy() *CRDRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CRDRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDRestoreSpec) DeepCopyInto(out *CRDRestoreSpec) {
+	*out = *in
+	out.Backup = in.Backup
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDRestoreSpec.
+func (in *CRDRestoreSpec) DeepCopy() *CRDRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDRestoreStatus) DeepCopyInto(out *CRDRestoreStatus) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]RestoredObjectStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDRestoreStatus.
+func (in *CRDRestoreStatus) DeepCopy() *CRDRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDSelector) DeepCopyInto(out *CRDSelector) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = (*in).DeepCopy()
+	}
+	if in.Categories != nil {
+		in, out := &in.Categories, &out.Categories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDSelector.
+func (in *CRDSelector) DeepCopy() *CRDSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDVersionLifecycleStatus) DeepCopyInto(out *CRDVersionLifecycleStatus) {
+	*out = *in
+	if in.DeprecatedTime != nil {
+		in, out := &in.DeprecatedTime, &out.DeprecatedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.UnservedTime != nil {
+		in, out := &in.UnservedTime, &out.UnservedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RemovedTime != nil {
+		in, out := &in.RemovedTime, &out.RemovedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDVersionLifecycleStatus.
+func (in *CRDVersionLifecycleStatus) DeepCopy() *CRDVersionLifecycleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDVersionLifecycleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCRDCleanupPolicy) DeepCopyInto(out *ClusterCRDCleanupPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCRDCleanupPolicy.
+func (in *ClusterCRDCleanupPolicy) DeepCopy() *ClusterCRDCleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCRDCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCRDCleanupPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCRDCleanupPolicyList) DeepCopyInto(out *ClusterCRDCleanupPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterCRDCleanupPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCRDCleanupPolicyList.
+func (in *ClusterCRDCleanupPolicyList) DeepCopy() *ClusterCRDCleanupPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCRDCleanupPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterCRDCleanupPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeprecatedVersionsTarget) DeepCopyInto(out *DeprecatedVersionsTarget) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(CRDSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeprecatedVersionsTarget.
+func (in *DeprecatedVersionsTarget) DeepCopy() *DeprecatedVersionsTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(DeprecatedVersionsTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSchedule) DeepCopyInto(out *MaintenanceSchedule) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.NotBefore != nil {
+		in, out := &in.NotBefore, &out.NotBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.NotAfter != nil {
+		in, out := &in.NotAfter, &out.NotAfter
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSchedule.
+func (in *MaintenanceSchedule) DeepCopy() *MaintenanceSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProtectionAcknowledgement) DeepCopyInto(out *ProtectionAcknowledgement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProtectionAcknowledgement.
+func (in *ProtectionAcknowledgement) DeepCopy() *ProtectionAcknowledgement {
+	if in == nil {
+		return nil
+	}
+	out := new(ProtectionAcknowledgement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestoredObjectStatus) DeepCopyInto(out *RestoredObjectStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestoredObjectStatus.
+func (in *RestoredObjectStatus) DeepCopy() *RestoredObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RestoredObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnusedCRDCollection) DeepCopyInto(out *UnusedCRDCollection) {
+	*out = *in
+	out.QuietPeriod = in.QuietPeriod
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(CRDSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnusedCRDCollection.
+func (in *UnusedCRDCollection) DeepCopy() *UnusedCRDCollection {
+	if in == nil {
+		return nil
+	}
+	out := new(UnusedCRDCollection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionLifecycle) DeepCopyInto(out *VersionLifecycle) {
+	*out = *in
+	if in.DeprecationPeriod != nil {
+		in, out := &in.DeprecationPeriod, &out.DeprecationPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.UnservedPeriod != nil {
+		in, out := &in.UnservedPeriod, &out.UnservedPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionLifecycle.
+func (in *VersionLifecycle) DeepCopy() *VersionLifecycle {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionLifecycle)
+	in.DeepCopyInto(out)
+	return out
+}
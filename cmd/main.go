@@ -19,17 +19,24 @@ package main
 import (
 	"crypto/tls"
 	"flag"
+	"fmt"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -37,7 +44,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/backup"
 	"github.com/kubecrew/kreepy/internal/controller"
+	"github.com/kubecrew/kreepy/internal/protection"
+	webhookv1alpha1 "github.com/kubecrew/kreepy/internal/webhook/v1alpha1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	// +kubebuilder:scaffold:imports
 )
@@ -62,7 +72,19 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enforceGrants bool
+	var authorizeRequester bool
+	var protectedCRDs string
+	var backupSink string
+	var backupDirectory string
+	var backupNamespace string
+	var backupEncryptionSecret string
+	var backupAllowUnencryptedReads bool
+	var emergencyStop bool
+	var emergencyStopConfigMap string
 	var tlsOpts []func(*tls.Config)
+	// Requesters are recorded by the mutating webhook, so they are only authorized by default if it runs
+	webhooksEnabled := os.Getenv("ENABLE_WEBHOOKS") != "false"
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -73,6 +95,30 @@ func main() {
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enforceGrants, "enforce-grants", true,
+		"If set, CRDCleanupPolicies may only target the CRDs their namespace is granted by a CRDCleanupGrant.")
+	flag.BoolVar(&authorizeRequester, "authorize-requester", webhooksEnabled,
+		"If set, policy entries are only processed if the user that requested the policy may delete or update the CRD. "+
+			"Defaults to true unless ENABLE_WEBHOOKS=false, since the requester is recorded by the webhook.")
+	flag.StringVar(&protectedCRDs, "protected-crds", strings.Join(protection.DefaultCRDs, ","),
+		"Comma separated glob patterns of CRD names that policy entries may only target if they acknowledge the protection.")
+	flag.StringVar(&backupSink, "backup-sink", "",
+		"If set, CRDs and their instances are backed up before they are removed. "+
+			"One of Directory, ConfigMap or Secret.")
+	flag.StringVar(&backupDirectory, "backup-directory", "/backups",
+		"The directory backups are written to if --backup-sink=Directory, e.g. a mounted PersistentVolumeClaim.")
+	flag.StringVar(&backupNamespace, "backup-namespace", "",
+		"The namespace of the ConfigMaps or Secrets backups are written to if --backup-sink is ConfigMap or Secret.")
+	flag.StringVar(&backupEncryptionSecret, "backup-encryption-secret", "",
+		"If set, backups are encrypted with the keys of this Secret, given as <namespace>/<name>.")
+	flag.BoolVar(&backupAllowUnencryptedReads, "backup-allow-unencrypted-reads", false,
+		"If set with --backup-encryption-secret, backups that are not encrypted can still be restored, "+
+			"e.g. the backups written before encryption was enabled.")
+	flag.BoolVar(&emergencyStop, "emergency-stop", false,
+		"If set, the operator makes no deletions and updates at all, but still evaluates policies and reports their status.")
+	flag.StringVar(&emergencyStopConfigMap, "emergency-stop-configmap", "",
+		"If set, the operator stops like with --emergency-stop while the \"stop\" key of this ConfigMap, "+
+			"given as <namespace>/<name>, is \"true\".")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -124,8 +170,20 @@ func main() {
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
 	}
 
+	if authorizeRequester && !webhooksEnabled {
+		setupLog.Info("--authorize-requester is set, but webhooks are disabled, so entries of policies " +
+			"created or updated from now on are denied because no requester is recorded")
+	}
+
+	stop, err := newEmergencyStop(emergencyStop, emergencyStopConfigMap)
+	if err != nil {
+		setupLog.Error(err, "unable to configure the emergency stop")
+		os.Exit(1)
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  emergencyStopCacheOptions(stop),
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
@@ -148,15 +206,58 @@ func main() {
 		os.Exit(1)
 	}
 
+	backups, err := newBackupSink(mgr, backupSink, backupDirectory, backupNamespace)
+	if err != nil {
+		setupLog.Error(err, "unable to configure the backup sink")
+		os.Exit(1)
+	}
+	if backupEncryptionSecret != "" {
+		backups, err = newEncryptedSink(mgr, backups, backupEncryptionSecret, backupAllowUnencryptedReads)
+		if err != nil {
+			setupLog.Error(err, "unable to configure the backup encryption")
+			os.Exit(1)
+		}
+	}
+
 	if err = (&controller.CRDCleanupPolicyReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:             mgr.GetClient(),
+		Scheme:             mgr.GetScheme(),
+		APIReader:          mgr.GetAPIReader(),
+		EnforceGrants:      enforceGrants,
+		AuthorizeRequester: authorizeRequester,
+		ProtectedCRDs:      splitPatterns(protectedCRDs),
+		Backups:            backups,
+		EmergencyStop:      stop,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "CRDCleanupPolicy")
 		os.Exit(1)
 	}
+	if err = (&controller.CRDRestoreReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		APIReader:     mgr.GetAPIReader(),
+		Backups:       backups,
+		EmergencyStop: stop,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CRDRestore")
+		os.Exit(1)
+	}
+	if webhooksEnabled {
+		if err = webhookv1alpha1.SetupCRDCleanupPolicyWebhookWithManager(mgr, splitPatterns(protectedCRDs)); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CRDCleanupPolicy")
+			os.Exit(1)
+		}
+		if err = webhookv1alpha1.SetupClusterCRDCleanupPolicyWebhookWithManager(mgr, splitPatterns(protectedCRDs)); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterCRDCleanupPolicy")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	if stop.Engaged {
+		setupLog.Info("emergency stop is engaged, no deletions and updates are made")
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -172,3 +273,86 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// splitPatterns splits a comma separated list of patterns, ignoring empty patterns
+func splitPatterns(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// newBackupSink returns the backup sink of the given type, or nil if backups are disabled
+func newBackupSink(mgr ctrl.Manager, sinkType, directory, namespace string) (backup.Sink, error) {
+	switch policiesv1alpha1.BackupSinkType(sinkType) {
+	case "":
+		return nil, nil
+	case policiesv1alpha1.BackupSinkDirectory:
+		if info, err := os.Stat(directory); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("backup directory %s does not exist", directory)
+		}
+		return &backup.DirectorySink{Path: directory}, nil
+	case policiesv1alpha1.BackupSinkConfigMap, policiesv1alpha1.BackupSinkSecret:
+		if namespace == "" {
+			return nil, fmt.Errorf("--backup-namespace is required for --backup-sink=%s", sinkType)
+		}
+		return &backup.ChunkedSink{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Namespace: namespace,
+			Secret:    sinkType == string(policiesv1alpha1.BackupSinkSecret),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown backup sink %s", sinkType)
+	}
+}
+
+// newEncryptedSink returns a sink encrypting the backups written to the given sink with the keys of the given Secret.
+// Backups that are not encrypted are only read if allowPlaintext is set.
+func newEncryptedSink(mgr ctrl.Manager, sink backup.Sink, secret string, allowPlaintext bool) (backup.Sink, error) {
+	if sink == nil {
+		return nil, fmt.Errorf("--backup-encryption-secret requires --backup-sink")
+	}
+	namespace, name, ok := strings.Cut(secret, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("--backup-encryption-secret must be <namespace>/<name>, not %s", secret)
+	}
+	return &backup.EncryptedSink{
+		Sink:           sink,
+		Keys:           &backup.SecretKeyring{Reader: mgr.GetAPIReader(), Namespace: namespace, Name: name},
+		AllowPlaintext: allowPlaintext,
+	}, nil
+}
+
+// newEmergencyStop returns the emergency stop engaged by the flag and the given ConfigMap, if any
+func newEmergencyStop(engaged bool, configMap string) (*controller.EmergencyStop, error) {
+	stop := &controller.EmergencyStop{Engaged: engaged}
+	if configMap == "" {
+		return stop, nil
+	}
+	namespace, name, ok := strings.Cut(configMap, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("--emergency-stop-configmap must be <namespace>/<name>, not %s", configMap)
+	}
+	stop.ConfigMap = types.NamespacedName{Namespace: namespace, Name: name}
+	return stop, nil
+}
+
+// emergencyStopCacheOptions restricts the cached ConfigMaps to the emergency stop ConfigMap, so watching it does not
+// cache all ConfigMaps of the cluster. Backups are read directly from the API server and are not affected.
+func emergencyStopCacheOptions(stop *controller.EmergencyStop) cache.Options {
+	if stop.ConfigMap.Name == "" {
+		return cache.Options{}
+	}
+	return cache.Options{
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.ConfigMap{}: {
+				Namespaces: map[string]cache.Config{stop.ConfigMap.Namespace: {}},
+				Field:      fields.OneTermEqualSelector("metadata.name", stop.ConfigMap.Name),
+			},
+		},
+	}
+}
@@ -25,9 +25,16 @@ echo "Loading the operator image into kind..."
 kind load docker-image "$IMAGE_NAME" --name "$CLUSTER_NAME"
 echo ""
 
+# Install cert-manager for the serving certificate of the webhook
+echo "Installing cert-manager..."
+kubectl apply -f https://github.com/jetstack/cert-manager/releases/download/v1.14.4/cert-manager.yaml
+kubectl wait deployment.apps/cert-manager-webhook -n cert-manager --for=condition=Available --timeout=5m
+echo ""
+
 # Deploy the operator using make
 echo "Deploying the operator into the cluster..."
 make deploy IMG="$IMAGE_NAME"
+kubectl rollout status deployment/kreepy-controller-manager -n kreepy-system --timeout=120s
 echo ""
 
 # Install sample crd
@@ -39,7 +46,8 @@ kubectl apply -f hack/multisample.yaml
 echo ""
 
 # Deploy the CRD Cleanup Policy
-echo "Deploying the CRD Cleanup Policy..."
+echo "Deploying the CRD Cleanup Grant and Policy..."
+kubectl apply -f hack/crd-cleanup-grant.yaml
 kubectl apply -f hack/crd-cleanup-policy.yaml
 echo ""
 
@@ -49,16 +57,16 @@ OPERATOR_POD=$(kubectl get pods -n kreepy-system -l control-plane=controller-man
 kubectl wait --for=condition=Ready pod/"$OPERATOR_POD" -n kreepy-system --timeout=120s
 echo ""
 
-# Check for remaining CRDs
-echo "Checking for remaining CRDs..."
-echo "Expecting to see remaining CRDs in the status of crdcleanuppolicy-sample..."
-REMAINING_CRDS=$(kubectl get crdcleanuppolicy crdcleanuppolicy-sample -n default -o jsonpath='{.status.remainingCrds}')
+# Check for blocked CRDs
+echo "Checking for blocked CRDs..."
+echo "Expecting to see samples.example.com blocked in the status of crdcleanuppolicy-sample..."
+SAMPLES_PHASE=$(kubectl get crdcleanuppolicy crdcleanuppolicy-sample -n default -o jsonpath='{.status.entries[?(@.name=="samples.example.com")].phase}')
 
-if [[ -n "$REMAINING_CRDS" && "$REMAINING_CRDS" != "[]" ]]; then
-    echo "✅ Remaining CRDs detected: $REMAINING_CRDS. Test PASSED."
+if [[ "$SAMPLES_PHASE" == "Blocked" ]]; then
+    echo "✅ samples.example.com is blocked by its instances. Test PASSED."
     echo ""
 else
-    echo "❌ No remaining CRDs found. Test FAILED."
+    echo "❌ samples.example.com is in phase '$SAMPLES_PHASE' instead of Blocked. Test FAILED."
     exit 1
 fi
 
@@ -82,13 +90,13 @@ echo "Expecting to see samples.example.com CRD removed after deleting all sample
 kubectl delete samples.example.com --all -n default
 echo "Waiting one minute for the samples.example.com CRD to be removed to test the reconciliation after requeue..."
 sleep 60
-REMAINING_CRDS=$(kubectl get crdcleanuppolicy crdcleanuppolicy-sample -n default -o jsonpath='{.status.remainingCrds}')
+SAMPLES_PHASE=$(kubectl get crdcleanuppolicy crdcleanuppolicy-sample -n default -o jsonpath='{.status.entries[?(@.name=="samples.example.com")].phase}')
 
-if [[ -z "$REMAINING_CRDS" || "$REMAINING_CRDS" == "[]" ]]; then
-    echo "✅ No remaining CRDs found. Test PASSED."
+if [[ "$SAMPLES_PHASE" == "Deleting" || "$SAMPLES_PHASE" == "Deleted" ]]; then
+    echo "✅ samples.example.com is in phase $SAMPLES_PHASE. Test PASSED."
     echo ""
 else
-    echo "❌ Remaining CRDs detected: $REMAINING_CRDS. Test FAILED."
+    echo "❌ samples.example.com is in phase '$SAMPLES_PHASE'. Test FAILED."
     exit 1
 fi
 
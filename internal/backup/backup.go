@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backup snapshots CRDs and their instances before the operator deletes them and writes the snapshots
+// to a sink, such as a directory on a PersistentVolumeClaim or chunked ConfigMaps and Secrets. Backups can be
+// encrypted with keys read from a Secret.
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// Backup is the snapshot of a CRD and all of its instances.
+type Backup struct {
+	// CRD is the CustomResourceDefinition without its status and server-managed fields
+	CRD *unstructured.Unstructured `json:"crd"`
+
+	// Instances are the instances of the CRD without their server-managed fields
+	Instances []unstructured.Unstructured `json:"instances"`
+}
+
+// Sink stores backups.
+type Sink interface {
+	// Type returns the kind of storage of the sink
+	Type() policiesv1alpha1.BackupSinkType
+
+	// Write stores the data of a backup under the given name and returns a reference to it
+	Write(ctx context.Context, name string, data []byte) (policiesv1alpha1.BackupReference, error)
+
+	// Read returns the data of the referenced backup
+	Read(ctx context.Context, ref policiesv1alpha1.BackupReference) ([]byte, error)
+
+	// Name returns the name the referenced backup was written with
+	Name(ref policiesv1alpha1.BackupReference) (string, error)
+}
+
+// Encode serializes the backup
+func Encode(backup *Backup) ([]byte, error) {
+	return json.Marshal(backup)
+}
+
+// Decode deserializes a backup serialized by Encode
+func Decode(data []byte) (*Backup, error) {
+	backup := &Backup{}
+	if err := json.Unmarshal(data, backup); err != nil {
+		return nil, fmt.Errorf("failed to decode backup: %w", err)
+	}
+	if backup.CRD == nil {
+		return nil, fmt.Errorf("backup contains no CRD")
+	}
+	return backup, nil
+}
+
+// Clean removes the fields managed by the API server from the object, so it can be created again.
+// The status is kept for instances, but dropped for CRDs, since the API server computes it. Owner references
+// are kept, so a restore can re-create owners first and link the instances to their new UIDs.
+func Clean(obj *unstructured.Unstructured) {
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "deletionTimestamp",
+		"deletionGracePeriodSeconds", "managedFields", "selfLink"} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	if obj.GetKind() == "CustomResourceDefinition" {
+		unstructured.RemoveNestedField(obj.Object, "status")
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("Backup", func() {
+	ctx := context.Background()
+
+	Context("When cleaning objects", func() {
+		It("should remove server-managed fields and the status of CRDs", func() {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": "apiextensions.k8s.io/v1",
+				"kind":       "CustomResourceDefinition",
+				"metadata": map[string]interface{}{
+					"name":            "samples.example.com",
+					"uid":             "1234",
+					"resourceVersion": "42",
+					"managedFields":   []interface{}{},
+					"labels":          map[string]interface{}{"app": "sample"},
+				},
+				"status": map[string]interface{}{"storedVersions": []interface{}{"v1"}},
+			}}
+			Clean(obj)
+			Expect(obj.GetUID()).To(BeEmpty())
+			Expect(obj.GetResourceVersion()).To(BeEmpty())
+			Expect(obj.GetManagedFields()).To(BeEmpty())
+			Expect(obj.GetLabels()).To(HaveKeyWithValue("app", "sample"))
+			Expect(obj.Object).NotTo(HaveKey("status"))
+		})
+	})
+
+	Context("When writing backups to a directory", func() {
+		It("should read back the written backup", func() {
+			sink := &DirectorySink{Path: GinkgoT().TempDir()}
+			ref, err := sink.Write(ctx, "samples.example.com-1", []byte("backup"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ref.Sink).To(Equal(policiesv1alpha1.BackupSinkDirectory))
+
+			data, err := sink.Read(ctx, ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal([]byte("backup")))
+		})
+
+		It("should refuse to read files outside of the directory", func() {
+			sink := &DirectorySink{Path: GinkgoT().TempDir()}
+			_, err := sink.Read(ctx, policiesv1alpha1.BackupReference{Sink: policiesv1alpha1.BackupSinkDirectory, Location: "/etc/passwd"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("When writing backups to chunked objects", func() {
+		DescribeTable("should split the backup into chunks and read it back",
+			func(secret bool) {
+				scheme := runtime.NewScheme()
+				Expect(corev1.AddToScheme(scheme)).To(Succeed())
+				sink := &ChunkedSink{
+					Client:    fake.NewClientBuilder().WithScheme(scheme).Build(),
+					Namespace: "kreepy-system",
+					Secret:    secret,
+					ChunkSize: 4,
+				}
+
+				payload := bytes.Repeat([]byte("0123456789"), 3)
+				ref, err := sink.Write(ctx, "samples.example.com-1", payload)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ref.Location).To(Equal("samples.example.com-1-0"))
+				Expect(ref.Namespace).To(Equal("kreepy-system"))
+
+				data, err := sink.Read(ctx, ref)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(data).To(Equal(payload))
+			},
+			Entry("in ConfigMaps", false),
+			Entry("in Secrets", true),
+		)
+
+		It("should refuse to read chunks outside of its namespace", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			foreign := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "forged-0", Namespace: "default", Annotations: map[string]string{chunksAnnotation: "1"}},
+				BinaryData: map[string][]byte{chunkDataKey: []byte("forged")},
+			}
+			sink := &ChunkedSink{
+				Client:    fake.NewClientBuilder().WithScheme(scheme).WithObjects(foreign).Build(),
+				Namespace: "kreepy-system",
+			}
+
+			_, err := sink.Read(ctx, policiesv1alpha1.BackupReference{Sink: policiesv1alpha1.BackupSinkConfigMap, Location: "forged-0", Namespace: "default"})
+			Expect(err).To(MatchError(ContainSubstring("not located in namespace kreepy-system")))
+		})
+
+		It("should delete the written chunks if a later chunk cannot be created", func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "samples.example.com-1-0", Namespace: "kreepy-system"}}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+			sink := &ChunkedSink{Client: c, Namespace: "kreepy-system", ChunkSize: 4}
+
+			_, err := sink.Write(ctx, "samples.example.com-1", bytes.Repeat([]byte("0123456789"), 3))
+			Expect(apierrors.IsAlreadyExists(err)).To(BeTrue())
+
+			configMaps := &corev1.ConfigMapList{}
+			Expect(c.List(ctx, configMaps)).To(Succeed())
+			Expect(configMaps.Items).To(HaveLen(1))
+			Expect(configMaps.Items[0].Name).To(Equal(existing.Name))
+		})
+	})
+
+	Context("When encrypting backups", func() {
+		var keys *corev1.Secret
+		var sink *EncryptedSink
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			keys = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "backup-keys", Namespace: "kreepy-system"},
+				Data:       map[string][]byte{"key-1": bytes.Repeat([]byte{1}, 32)},
+			}
+			reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(keys).Build()
+			sink = &EncryptedSink{
+				Sink: &DirectorySink{Path: GinkgoT().TempDir()},
+				Keys: &SecretKeyring{Reader: reader, Namespace: "kreepy-system", Name: "backup-keys"},
+			}
+		})
+
+		It("should only store the encrypted backup and decrypt it when read", func() {
+			ref, err := sink.Write(ctx, "samples.example.com-1", []byte("secret backup"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ref.KeyID).To(Equal("key-1"))
+
+			stored, err := sink.Sink.Read(ctx, ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(stored)).NotTo(ContainSubstring("secret backup"))
+			Expect(string(stored)).To(ContainSubstring(`"keyID":"key-1"`))
+
+			data, err := sink.Read(ctx, ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal([]byte("secret backup")))
+		})
+
+		It("should read backups encrypted with a rotated key", func() {
+			old, err := sink.Write(ctx, "samples.example.com-1", []byte("old backup"))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("adding and selecting a new key")
+			keyring := sink.Keys.(*SecretKeyring)
+			Expect(keyring.Reader.Get(ctx, client.ObjectKeyFromObject(keys), keys)).To(Succeed())
+			keys.Data["key-2"] = bytes.Repeat([]byte{2}, 32)
+			keys.Annotations = map[string]string{ActiveKeyAnnotation: "key-2"}
+			Expect(keyring.Reader.(client.Client).Update(ctx, keys)).To(Succeed())
+
+			ref, err := sink.Write(ctx, "samples.example.com-2", []byte("new backup"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ref.KeyID).To(Equal("key-2"))
+
+			data, err := sink.Read(ctx, old)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal([]byte("old backup")))
+		})
+
+		It("should reject backups that were tampered with", func() {
+			ref, err := sink.Write(ctx, "samples.example.com-1", []byte("secret backup"))
+			Expect(err).NotTo(HaveOccurred())
+
+			By("pointing the backup to another key with the same bytes")
+			keyring := sink.Keys.(*SecretKeyring)
+			Expect(keyring.Reader.Get(ctx, client.ObjectKeyFromObject(keys), keys)).To(Succeed())
+			keys.Data["key-2"] = keys.Data["key-1"]
+			Expect(keyring.Reader.(client.Client).Update(ctx, keys)).To(Succeed())
+			stored, err := sink.Sink.Read(ctx, ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(os.WriteFile(ref.Location, bytes.Replace(stored, []byte(`"keyID":"key-1"`), []byte(`"keyID":"key-2"`), 1), 0o600)).To(Succeed())
+
+			_, err = sink.Read(ctx, ref)
+			Expect(err).To(MatchError(ContainSubstring("failed to decrypt")))
+		})
+
+		It("should reject backups that were moved to another location", func() {
+			ref, err := sink.Write(ctx, "samples.example.com-1", []byte("secret backup"))
+			Expect(err).NotTo(HaveOccurred())
+			stored, err := sink.Sink.Read(ctx, ref)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("replaying the backup under the name of another backup")
+			replayed := ref
+			replayed.Location = filepath.Join(filepath.Dir(ref.Location), "others.example.com-2.json")
+			Expect(os.WriteFile(replayed.Location, stored, 0o600)).To(Succeed())
+			_, err = sink.Read(ctx, replayed)
+			Expect(err).To(MatchError(ContainSubstring("was written as samples.example.com-1")))
+
+			By("renaming the backup in its envelope as well")
+			renamed := bytes.Replace(stored, []byte(`"name":"samples.example.com-1"`), []byte(`"name":"others.example.com-2"`), 1)
+			Expect(os.WriteFile(replayed.Location, renamed, 0o600)).To(Succeed())
+			_, err = sink.Read(ctx, replayed)
+			Expect(err).To(MatchError(ContainSubstring("failed to decrypt")))
+		})
+
+		It("should refuse to read backups that are not encrypted", func() {
+			ref, err := sink.Sink.Write(ctx, "samples.example.com-1", []byte(`{"crd":{}}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = sink.Read(ctx, ref)
+			Expect(err).To(MatchError(ContainSubstring("is not encrypted")))
+
+			By("dropping the ciphertext of an encrypted backup")
+			ref, err = sink.Sink.Write(ctx, "samples.example.com-2", []byte(`{"algorithm":"AES-256-GCM","keyID":"key-1","crd":{}}`))
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sink.Read(ctx, ref)
+			Expect(err).To(MatchError(ContainSubstring("is not encrypted")))
+		})
+
+		It("should return backups written before encryption was enabled unchanged if plaintext is allowed", func() {
+			sink.AllowPlaintext = true
+			ref, err := sink.Sink.Write(ctx, "samples.example.com-1", []byte(`{"crd":{}}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := sink.Read(ctx, ref)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(Equal([]byte(`{"crd":{}}`)))
+		})
+	})
+})
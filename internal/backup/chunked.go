@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+const (
+	// DefaultChunkSize is the number of bytes stored per ConfigMap or Secret, well below the 1MiB object limit
+	DefaultChunkSize = 512 * 1024
+
+	// chunkDataKey is the key of the chunk in the data of a ConfigMap or Secret
+	chunkDataKey = "backup"
+
+	// chunksAnnotation is the number of chunks of a backup, recorded on its first chunk
+	chunksAnnotation = "policies.kreepy.kubecrew.de/backup-chunks"
+
+	// backupLabel marks ConfigMaps and Secrets holding backup chunks
+	backupLabel = "policies.kreepy.kubecrew.de/backup"
+)
+
+// ChunkedSink writes every backup to a series of ConfigMaps or Secrets named <name>-<index>.
+type ChunkedSink struct {
+	Client client.Client
+
+	// APIReader reads the chunks directly from the API server, so ConfigMaps and Secrets are not cached.
+	// If it is nil, the Client is used.
+	APIReader client.Reader
+
+	// Namespace is the namespace the ConfigMaps or Secrets are created in
+	Namespace string
+
+	// Secret writes the chunks to Secrets instead of ConfigMaps
+	Secret bool
+
+	// ChunkSize is the number of bytes stored per object. DefaultChunkSize is used if it is zero.
+	ChunkSize int
+}
+
+var _ Sink = &ChunkedSink{}
+
+// Type implements Sink
+func (s *ChunkedSink) Type() policiesv1alpha1.BackupSinkType {
+	if s.Secret {
+		return policiesv1alpha1.BackupSinkSecret
+	}
+	return policiesv1alpha1.BackupSinkConfigMap
+}
+
+// Write implements Sink. The first chunk is created last, so a backup can only be read once all chunks exist.
+// If a chunk cannot be created, the chunks created before are deleted again.
+func (s *ChunkedSink) Write(ctx context.Context, name string, data []byte) (policiesv1alpha1.BackupReference, error) {
+	chunkSize := s.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	chunks := [][]byte{}
+	for len(data) > chunkSize {
+		chunks = append(chunks, data[:chunkSize])
+		data = data[chunkSize:]
+	}
+	chunks = append(chunks, data)
+
+	for i := len(chunks) - 1; i >= 0; i-- {
+		obj := s.newChunk(chunkName(name, i), chunks[i])
+		obj.SetLabels(map[string]string{backupLabel: "true"})
+		if i == 0 {
+			obj.SetAnnotations(map[string]string{chunksAnnotation: strconv.Itoa(len(chunks))})
+		}
+		if err := s.Client.Create(ctx, obj); err != nil {
+			return policiesv1alpha1.BackupReference{}, s.deleteChunks(ctx, name, i+1, len(chunks), err)
+		}
+	}
+	return policiesv1alpha1.BackupReference{Sink: s.Type(), Location: chunkName(name, 0), Namespace: s.Namespace}, nil
+}
+
+// Read implements Sink
+func (s *ChunkedSink) Read(ctx context.Context, ref policiesv1alpha1.BackupReference) ([]byte, error) {
+	if ref.Sink != s.Type() {
+		return nil, fmt.Errorf("backup is stored in a %s, not a %s", ref.Sink, s.Type())
+	}
+	// Backups are only read from the namespace of the sink, the namespace of the reference is not trusted since
+	// anyone who can create a CRDRestore chooses it
+	if ref.Namespace != "" && ref.Namespace != s.Namespace {
+		return nil, fmt.Errorf("backup %s/%s is not located in namespace %s", ref.Namespace, ref.Location, s.Namespace)
+	}
+	first, err := s.readChunk(ctx, s.Namespace, ref.Location)
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(first.GetAnnotations()[chunksAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("backup %s/%s has no valid chunk count: %w", s.Namespace, ref.Location, err)
+	}
+
+	name, err := s.Name(ref)
+	if err != nil {
+		return nil, err
+	}
+	var data bytes.Buffer
+	data.Write(chunkData(first))
+	for i := 1; i < count; i++ {
+		chunk, err := s.readChunk(ctx, s.Namespace, chunkName(name, i))
+		if err != nil {
+			return nil, err
+		}
+		data.Write(chunkData(chunk))
+	}
+	return data.Bytes(), nil
+}
+
+// Name implements Sink
+func (s *ChunkedSink) Name(ref policiesv1alpha1.BackupReference) (string, error) {
+	name, ok := chunkPrefix(ref.Location)
+	if !ok {
+		return "", fmt.Errorf("backup %s/%s is not the first chunk of a backup", s.Namespace, ref.Location)
+	}
+	return name, nil
+}
+
+// deleteChunks deletes the chunks of a partially written backup with an index in [from, to) and returns the error
+// that aborted the write, joined with any error deleting them
+func (s *ChunkedSink) deleteChunks(ctx context.Context, name string, from, to int, writeErr error) error {
+	errs := []error{writeErr}
+	for i := from; i < to; i++ {
+		if err := s.Client.Delete(ctx, s.newChunk(chunkName(name, i), nil)); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete chunk %s/%s: %w", s.Namespace, chunkName(name, i), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newChunk returns a ConfigMap or Secret holding the given data
+func (s *ChunkedSink) newChunk(name string, data []byte) client.Object {
+	meta := metav1.ObjectMeta{Name: name, Namespace: s.Namespace}
+	if s.Secret {
+		return &corev1.Secret{ObjectMeta: meta, Type: corev1.SecretTypeOpaque, Data: map[string][]byte{chunkDataKey: data}}
+	}
+	return &corev1.ConfigMap{ObjectMeta: meta, BinaryData: map[string][]byte{chunkDataKey: data}}
+}
+
+// readChunk fetches a ConfigMap or Secret holding a chunk
+func (s *ChunkedSink) readChunk(ctx context.Context, namespace, name string) (client.Object, error) {
+	var obj client.Object = &corev1.ConfigMap{}
+	if s.Secret {
+		obj = &corev1.Secret{}
+	}
+	var reader client.Reader = s.Client
+	if s.APIReader != nil {
+		reader = s.APIReader
+	}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// chunkData returns the chunk stored in a ConfigMap or Secret
+func chunkData(obj client.Object) []byte {
+	if secret, ok := obj.(*corev1.Secret); ok {
+		return secret.Data[chunkDataKey]
+	}
+	return obj.(*corev1.ConfigMap).BinaryData[chunkDataKey]
+}
+
+// chunkName returns the name of the ConfigMap or Secret holding the chunk with the given index
+func chunkName(name string, index int) string {
+	return fmt.Sprintf("%s-%d", name, index)
+}
+
+// chunkPrefix returns the name of the backup given the name of its first chunk
+func chunkPrefix(location string) (string, bool) {
+	return strings.CutSuffix(location, "-0")
+}
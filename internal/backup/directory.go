@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// DirectorySink writes every backup to a file in a directory, e.g. on a mounted PersistentVolumeClaim.
+type DirectorySink struct {
+	// Path is the directory the backups are written to
+	Path string
+}
+
+var _ Sink = &DirectorySink{}
+
+// Type implements Sink
+func (s *DirectorySink) Type() policiesv1alpha1.BackupSinkType {
+	return policiesv1alpha1.BackupSinkDirectory
+}
+
+// Write implements Sink. The file is written under a temporary name and renamed, so a backup is either
+// complete or missing.
+func (s *DirectorySink) Write(_ context.Context, name string, data []byte) (policiesv1alpha1.BackupReference, error) {
+	path := filepath.Join(s.Path, name+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return policiesv1alpha1.BackupReference{}, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return policiesv1alpha1.BackupReference{}, err
+	}
+	return policiesv1alpha1.BackupReference{Sink: s.Type(), Location: path}, nil
+}
+
+// Read implements Sink. Only files in the directory of the sink can be read.
+func (s *DirectorySink) Read(_ context.Context, ref policiesv1alpha1.BackupReference) ([]byte, error) {
+	if ref.Sink != s.Type() {
+		return nil, fmt.Errorf("backup is stored in a %s, not a %s", ref.Sink, s.Type())
+	}
+	path := filepath.Clean(ref.Location)
+	if !strings.HasPrefix(path, filepath.Clean(s.Path)+string(filepath.Separator)) {
+		return nil, fmt.Errorf("backup %s is not located in %s", ref.Location, s.Path)
+	}
+	return os.ReadFile(path)
+}
+
+// Name implements Sink
+func (s *DirectorySink) Name(ref policiesv1alpha1.BackupReference) (string, error) {
+	name, ok := strings.CutSuffix(filepath.Base(ref.Location), ".json")
+	if !ok {
+		return "", fmt.Errorf("backup %s is not a JSON file", ref.Location)
+	}
+	return name, nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+const (
+	// ActiveKeyAnnotation selects the key of the Secret new backups are encrypted with
+	ActiveKeyAnnotation = "policies.kreepy.kubecrew.de/active-key"
+
+	// algorithmAES256GCM is the AEAD backups are encrypted with
+	algorithmAES256GCM = "AES-256-GCM"
+
+	// keySize is the size of the keys in bytes
+	keySize = 32
+)
+
+// Keyring provides the keys backups are encrypted with.
+type Keyring interface {
+	// ActiveKey returns the ID of the key new backups are encrypted with and the key itself
+	ActiveKey(ctx context.Context) (string, []byte, error)
+
+	// Key returns the key with the given ID
+	Key(ctx context.Context, id string) ([]byte, error)
+}
+
+// EncryptedSink encrypts backups before writing them to another sink and decrypts them when they are read.
+type EncryptedSink struct {
+	// Sink stores the encrypted backups
+	Sink Sink
+
+	// Keys provides the keys. The ID of the key a backup is encrypted with is stored alongside the backup,
+	// so backups can be read as long as their key is available, even after the active key was rotated.
+	Keys Keyring
+
+	// AllowPlaintext makes Read return backups that are not encrypted unchanged, e.g. the backups written before
+	// encryption was enabled. Otherwise reading them fails, since anyone who can write to the sink could plant them.
+	AllowPlaintext bool
+}
+
+var _ Sink = &EncryptedSink{}
+
+// encryptedBackup is the envelope an encrypted backup is stored in
+type encryptedBackup struct {
+	// Algorithm is the AEAD the backup is encrypted with
+	Algorithm string `json:"algorithm"`
+
+	// KeyID is the ID of the key the backup is encrypted with. It is authenticated as additional data.
+	KeyID string `json:"keyID"`
+
+	// Name is the name the backup was written with. It is authenticated as additional data, so the backup cannot
+	// be read from another location than the one it was written to.
+	Name string `json:"name"`
+
+	// Nonce is the random nonce the backup is encrypted with
+	Nonce []byte `json:"nonce"`
+
+	// Ciphertext is the encrypted and authenticated backup
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Type implements Sink
+func (s *EncryptedSink) Type() policiesv1alpha1.BackupSinkType {
+	return s.Sink.Type()
+}
+
+// Write implements Sink. The backup is encrypted with the active key, whose ID is recorded in the reference.
+// The name of the backup, which contains the name of the CRD, is authenticated along with the backup.
+func (s *EncryptedSink) Write(ctx context.Context, name string, data []byte) (policiesv1alpha1.BackupReference, error) {
+	keyID, key, err := s.Keys.ActiveKey(ctx)
+	if err != nil {
+		return policiesv1alpha1.BackupReference{}, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return policiesv1alpha1.BackupReference{}, fmt.Errorf("invalid key %s: %w", keyID, err)
+	}
+	envelope := encryptedBackup{Algorithm: algorithmAES256GCM, KeyID: keyID, Name: name, Nonce: make([]byte, aead.NonceSize())}
+	if _, err := rand.Read(envelope.Nonce); err != nil {
+		return policiesv1alpha1.BackupReference{}, err
+	}
+	envelope.Ciphertext = aead.Seal(nil, envelope.Nonce, data, additionalData(keyID, name))
+	encrypted, err := json.Marshal(envelope)
+	if err != nil {
+		return policiesv1alpha1.BackupReference{}, err
+	}
+
+	ref, err := s.Sink.Write(ctx, name, encrypted)
+	if err != nil {
+		return policiesv1alpha1.BackupReference{}, err
+	}
+	ref.KeyID = keyID
+	return ref, nil
+}
+
+// Read implements Sink. Backups that are not encrypted are only returned unchanged if AllowPlaintext is set.
+// Encrypted backups are only returned if they were written with the name of the reference.
+func (s *EncryptedSink) Read(ctx context.Context, ref policiesv1alpha1.BackupReference) ([]byte, error) {
+	data, err := s.Sink.Read(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	envelope := encryptedBackup{}
+	if err := json.Unmarshal(data, &envelope); err != nil || envelope.Ciphertext == nil {
+		if s.AllowPlaintext {
+			return data, nil
+		}
+		return nil, fmt.Errorf("backup %s is not encrypted", ref.Location)
+	}
+	if envelope.Algorithm != algorithmAES256GCM {
+		return nil, fmt.Errorf("backup is encrypted with unsupported algorithm %s", envelope.Algorithm)
+	}
+	name, err := s.Name(ref)
+	if err != nil {
+		return nil, err
+	}
+	if envelope.Name != name {
+		return nil, fmt.Errorf("backup %s was written as %s, not %s", ref.Location, envelope.Name, name)
+	}
+
+	key, err := s.Keys.Key(ctx, envelope.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %s: %w", envelope.KeyID, err)
+	}
+	if len(envelope.Nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("backup has an invalid nonce")
+	}
+	plaintext, err := aead.Open(nil, envelope.Nonce, envelope.Ciphertext, additionalData(envelope.KeyID, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt backup with key %s: %w", envelope.KeyID, err)
+	}
+	return plaintext, nil
+}
+
+// Name implements Sink
+func (s *EncryptedSink) Name(ref policiesv1alpha1.BackupReference) (string, error) {
+	return s.Sink.Name(ref)
+}
+
+// additionalData returns the data authenticated along with a backup. Key IDs are keys of a Secret, so they
+// cannot contain the separator.
+func additionalData(keyID, name string) []byte {
+	return []byte(keyID + "/" + name)
+}
+
+// newAEAD returns the AES-256-GCM AEAD for the given key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, not %d", keySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SecretKeyring reads the keys from a Secret. Every entry of the Secret is a key, named by its ID. New backups are
+// encrypted with the key selected by the ActiveKeyAnnotation of the Secret, or its only key if it has just one.
+// Keys are rotated by adding a new key and selecting it, while keeping the previous keys to read older backups.
+type SecretKeyring struct {
+	// Reader reads the Secret. It should read directly from the API server, so Secrets are not cached.
+	Reader client.Reader
+
+	// Namespace is the namespace of the Secret
+	Namespace string
+
+	// Name is the name of the Secret
+	Name string
+}
+
+var _ Keyring = &SecretKeyring{}
+
+// ActiveKey implements Keyring
+func (k *SecretKeyring) ActiveKey(ctx context.Context) (string, []byte, error) {
+	secret, err := k.secret(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	keyID := secret.Annotations[ActiveKeyAnnotation]
+	if keyID == "" {
+		if len(secret.Data) != 1 {
+			return "", nil, fmt.Errorf("secret %s/%s has %d keys, but no %s annotation selecting one", k.Namespace, k.Name,
+				len(secret.Data), ActiveKeyAnnotation)
+		}
+		for id := range secret.Data {
+			keyID = id
+		}
+	}
+	key, ok := secret.Data[keyID]
+	if !ok {
+		return "", nil, fmt.Errorf("secret %s/%s has no active key %s, only %v", k.Namespace, k.Name, keyID, keyIDs(secret))
+	}
+	return keyID, key, nil
+}
+
+// Key implements Keyring
+func (k *SecretKeyring) Key(ctx context.Context, id string) ([]byte, error) {
+	secret, err := k.secret(ctx)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := secret.Data[id]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %s, only %v", k.Namespace, k.Name, id, keyIDs(secret))
+	}
+	return key, nil
+}
+
+// secret fetches the Secret holding the keys
+func (k *SecretKeyring) secret(ctx context.Context) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := k.Reader.Get(ctx, types.NamespacedName{Namespace: k.Namespace, Name: k.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get the encryption keys from secret %s/%s: %w", k.Namespace, k.Name, err)
+	}
+	return secret, nil
+}
+
+// keyIDs returns the sorted IDs of all keys of the Secret
+func keyIDs(secret *corev1.Secret) []string {
+	ids := make([]string, 0, len(secret.Data))
+	for id := range secret.Data {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// requester returns the user and groups recorded on the policy by the admission webhook
+func requester(policy cleanupPolicy) (string, []string) {
+	annotations := policy.GetAnnotations()
+	user := annotations[policiesv1alpha1.RequestedByAnnotation]
+	var groups []string
+	if value := annotations[policiesv1alpha1.RequestedByGroupsAnnotation]; value != "" {
+		groups = strings.Split(value, ",")
+	}
+	return user, groups
+}
+
+// authorizeRequester checks with SubjectAccessReviews whether the user that requested the policy may delete the CRD
+// of the entry, or update it if only a version is removed. Since the operator changes the instances of the CRD on
+// behalf of the user, the user must also be allowed to update them if a version is removed and to delete them if the
+// instance policy deletes them. It returns a message explaining why the entry is denied, or an empty string if the
+// user is allowed to process the entry.
+func (r *CRDCleanupPolicyReconciler) authorizeRequester(ctx context.Context, policy cleanupPolicy, entry *policiesv1alpha1.CRDCleanupEntryStatus, log logr.Logger) (string, error) {
+	user, groups := requester(policy)
+	if user == "" {
+		return "The policy has no recorded requester, update the policy with the admission webhook enabled to record one", nil
+	}
+
+	verb := "delete"
+	if entry.Version != "" {
+		verb = "update"
+	}
+	allowed, err := r.reviewAccess(ctx, user, groups, &authorizationv1.ResourceAttributes{
+		Verb:     verb,
+		Group:    v1.GroupName,
+		Version:  v1.SchemeGroupVersion.Version,
+		Resource: "customresourcedefinitions",
+		Name:     entry.Name,
+	}, log)
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return fmt.Sprintf("User %s is not allowed to %s the CRD", user, verb), nil
+	}
+
+	// Removing a version may rewrite the instances in the new storage version, unused CRDs never have instances
+	// to delete
+	switch instancePolicy := instancePolicyFor(policy, entry); {
+	case entry.Version != "":
+	case policy.GetSpec().UnusedCRDs == nil &&
+		(instancePolicy == policiesv1alpha1.InstancePolicyDelete || instancePolicy == policiesv1alpha1.InstancePolicyBackupThenDelete):
+	default:
+		return "", nil
+	}
+	crd, err := r.fetchCRDDefinition(ctx, entry.Name, log)
+	if err != nil || crd == nil {
+		return "", err
+	}
+	allowed, err = r.reviewAccess(ctx, user, groups, &authorizationv1.ResourceAttributes{
+		Verb:     verb,
+		Group:    crd.Spec.Group,
+		Resource: crd.Spec.Names.Plural,
+	}, log)
+	if err != nil {
+		return "", err
+	}
+	if !allowed {
+		return fmt.Sprintf("User %s is not allowed to %s the instances of the CRD", user, verb), nil
+	}
+	return "", nil
+}
+
+// reviewAccess returns whether the user with the given groups may access the resource
+func (r *CRDCleanupPolicyReconciler) reviewAccess(ctx context.Context, user string, groups []string, attributes *authorizationv1.ResourceAttributes, log logr.Logger) (bool, error) {
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               user,
+			Groups:             groups,
+			ResourceAttributes: attributes,
+		},
+	}
+	if err := r.Create(ctx, review); err != nil {
+		log.Error(err, "Failed to review the access of the requester", "Group", attributes.Group, "Resource", attributes.Resource, "User", user)
+		return false, err
+	}
+
+	if !review.Status.Allowed {
+		log.Info("Requester of the policy is not allowed to access the resource", "Group", attributes.Group, "Resource", attributes.Resource, "Name", attributes.Name, "User", user, "Verb", attributes.Verb, "Reason", review.Status.Reason)
+	}
+	return review.Status.Allowed, nil
+}
+
+// blockOnInstanceAccess blocks the entry if the error is caused by missing rights to change the instances of the
+// CRD, since only a cluster administrator can grant them. It returns false if the error has another cause.
+func blockOnInstanceAccess(entry *policiesv1alpha1.CRDCleanupEntryStatus, crd *v1.CustomResourceDefinition, err error) bool {
+	if !errors.IsForbidden(err) {
+		return false
+	}
+	setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseBlocked, instanceAccessHint(crd), err)
+	return true
+}
+
+// instanceAccessHint describes how to grant the operator access to the instances of the CRD
+func instanceAccessHint(crd *v1.CustomResourceDefinition) string {
+	return fmt.Sprintf("The operator may not change the instances of group %s, grant access with a ClusterRole labeled %s=true",
+		crd.Spec.Group, policiesv1alpha1.InstanceEditorLabel)
+}
+
+// instanceAccessError adds a hint how to grant the operator access to the instances of the CRD if the error is caused
+// by missing rights
+func instanceAccessError(err error, crd *v1.CustomResourceDefinition) error {
+	if !errors.IsForbidden(err) {
+		return err
+	}
+	return fmt.Errorf("%w, grant access to the instances of group %s with a ClusterRole labeled %s=true", err, crd.Spec.Group, policiesv1alpha1.InstanceEditorLabel)
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// SubjectAccessReviews are answered by the API server, so these tests run against a fake client that allows
+// everything except the denied resources
+var _ = Describe("Requester authorization", func() {
+	var reviewed []authorizationv1.ResourceAttributes
+
+	// newReconciler returns a reconciler that records the reviewed resources and denies the given verb on the
+	// instances of the test CRD
+	newReconciler := func(deniedVerb string) *CRDCleanupPolicyReconciler {
+		reviewed = nil
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(newTestCRD("auth.example.com", "samples", "Sample", "v1", "v2")).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					review, ok := obj.(*authorizationv1.SubjectAccessReview)
+					if !ok {
+						return c.Create(ctx, obj, opts...)
+					}
+					attributes := *review.Spec.ResourceAttributes
+					reviewed = append(reviewed, attributes)
+					review.Status.Allowed = attributes.Resource != "samples" || attributes.Verb != deniedVerb
+					return nil
+				},
+			}).
+			Build()
+		return &CRDCleanupPolicyReconciler{Client: c, Scheme: scheme.Scheme}
+	}
+
+	// newPolicy returns a policy requested by alice for the test CRD
+	newPolicy := func(instancePolicy policiesv1alpha1.InstancePolicy) *policiesv1alpha1.CRDCleanupPolicy {
+		return &policiesv1alpha1.CRDCleanupPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "authorized-policy",
+				Namespace:   "default",
+				Annotations: map[string]string{policiesv1alpha1.RequestedByAnnotation: "alice"},
+			},
+			Spec: policiesv1alpha1.CRDCleanupPolicySpec{InstancePolicy: instancePolicy},
+		}
+	}
+
+	It("should only review the CRD if the instances are kept", func() {
+		r := newReconciler("delete")
+		entry := &policiesv1alpha1.CRDCleanupEntryStatus{Name: "samples.auth.example.com"}
+		message, err := r.authorizeRequester(ctx, newPolicy(policiesv1alpha1.InstancePolicyBlock), entry, log.FromContext(ctx))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(BeEmpty())
+		Expect(reviewed).To(ConsistOf(HaveField("Resource", "customresourcedefinitions")))
+	})
+
+	It("should deny the entry if the requester may not delete the instances", func() {
+		r := newReconciler("delete")
+		entry := &policiesv1alpha1.CRDCleanupEntryStatus{Name: "samples.auth.example.com"}
+		message, err := r.authorizeRequester(ctx, newPolicy(policiesv1alpha1.InstancePolicyBackupThenDelete), entry, log.FromContext(ctx))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("User alice is not allowed to delete the instances of the CRD"))
+		Expect(reviewed).To(ContainElement(authorizationv1.ResourceAttributes{Verb: "delete", Group: "auth.example.com", Resource: "samples"}))
+	})
+
+	It("should deny the entry if the requester may not update the instances that are migrated", func() {
+		r := newReconciler("update")
+		entry := &policiesv1alpha1.CRDCleanupEntryStatus{Name: "samples.auth.example.com", Version: "v1"}
+		message, err := r.authorizeRequester(ctx, newPolicy(policiesv1alpha1.InstancePolicyBlock), entry, log.FromContext(ctx))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("User alice is not allowed to update the instances of the CRD"))
+	})
+
+	It("should not review the instances of unused CRDs", func() {
+		r := newReconciler("delete")
+		policy := newPolicy(policiesv1alpha1.InstancePolicyDelete)
+		policy.Spec.UnusedCRDs = &policiesv1alpha1.UnusedCRDCollection{}
+		entry := &policiesv1alpha1.CRDCleanupEntryStatus{Name: "samples.auth.example.com"}
+		message, err := r.authorizeRequester(ctx, policy, entry, log.FromContext(ctx))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(BeEmpty())
+	})
+
+	Context("When the requester of a policy is authorized", func() {
+		const resourceName = "unattributed-policy"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a policy without a recorded requester")
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{
+						{Name: "things.example.com"},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should deny entries of policies without a requester", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				AuthorizeRequester: true,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDenied))
+			Expect(policy.Status.Entries[0].Message).To(HavePrefix("The policy has no recorded requester"))
+		})
+	})
+})
+
+var _ = Describe("Instance access", func() {
+	crd := newTestCRD("access.example.com", "samples", "Sample", "v1")
+	forbidden := errors.NewForbidden(schema.GroupResource{Group: "access.example.com", Resource: "samples"}, "sample", fmt.Errorf("denied"))
+
+	It("should block entries the operator may not change the instances of until access is granted", func() {
+		policy := &policiesv1alpha1.CRDCleanupPolicy{}
+		policy.Status.Entries = []policiesv1alpha1.CRDCleanupEntryStatus{{Name: crd.Name, Phase: policiesv1alpha1.CRDCleanupPhasePending}}
+		entry := &policy.Status.Entries[0]
+
+		Expect(blockOnInstanceAccess(entry, crd, instanceAccessError(forbidden, crd))).To(BeTrue())
+		Expect(entry.Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseBlocked))
+		Expect(entry.Message).To(ContainSubstring(policiesv1alpha1.InstanceEditorLabel))
+		Expect(entry.LastError).NotTo(BeEmpty())
+		Expect(hasEntriesToRetry(policy)).To(BeTrue())
+	})
+
+	It("should leave entries failing for other reasons to the caller", func() {
+		entry := &policiesv1alpha1.CRDCleanupEntryStatus{Name: crd.Name, Phase: policiesv1alpha1.CRDCleanupPhasePending}
+		Expect(blockOnInstanceAccess(entry, crd, errors.NewConflict(schema.GroupResource{}, "sample", fmt.Errorf("conflict")))).To(BeFalse())
+		Expect(blockOnInstanceAccess(entry, crd, nil)).To(BeFalse())
+		Expect(entry.Phase).To(Equal(policiesv1alpha1.CRDCleanupPhasePending))
+	})
+
+	It("should only resync entries that are blocked by instances", func() {
+		policy := &policiesv1alpha1.CRDCleanupPolicy{}
+		policy.Status.Entries = []policiesv1alpha1.CRDCleanupEntryStatus{{Name: crd.Name, Phase: policiesv1alpha1.CRDCleanupPhaseBlocked}}
+		Expect(hasEntriesToRetry(policy)).To(BeFalse())
+	})
+})
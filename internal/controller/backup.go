@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/backup"
+)
+
+// maxBackupNameLength leaves room for the timestamp, the random suffix and the chunk index within the
+// 253 characters of an object name
+const maxBackupNameLength = 200
+
+// backupEntry snapshots the CRD and all of its instances and writes them to the backup sink. The reference to the
+// backup is recorded in the entry and persisted in the status of the policy before anything is deleted. Once the
+// entry has a backup, a new one is only taken if instances were created or changed since, it also keeps the
+// instances of the previous backup that were deleted in the meantime. Without a backup sink, nothing is backed up.
+func (r *CRDCleanupPolicyReconciler) backupEntry(ctx context.Context, policy cleanupPolicy, crd *v1.CustomResourceDefinition, entry *policiesv1alpha1.CRDCleanupEntryStatus, log logr.Logger) error {
+	if r.Backups == nil {
+		return nil
+	}
+
+	snapshot, err := r.snapshot(ctx, crd, log)
+	if err != nil {
+		return err
+	}
+	if entry.Backup != nil {
+		previous, err := r.readBackup(ctx, entry.Backup.BackupReference, log)
+		if err != nil {
+			return err
+		}
+		if !mergeBackups(snapshot, previous) {
+			return nil
+		}
+		log.Info("Instances of CRD changed since the last backup", "CRD", crd.Name, "Location", entry.Backup.Location)
+	}
+
+	data, err := backup.Encode(snapshot)
+	if err != nil {
+		log.Error(err, "Failed to encode the backup of CRD", "CRD", crd.Name)
+		return err
+	}
+	ref, err := r.Backups.Write(ctx, backupName(entry), data)
+	if err != nil {
+		log.Error(err, "Failed to write the backup of CRD", "CRD", crd.Name, "Sink", r.Backups.Type())
+		return err
+	}
+
+	log.Info("Backed up CRD", "CRD", crd.Name, "Sink", ref.Sink, "Location", ref.Location, "Instances", len(snapshot.Instances))
+	entry.Backup = &policiesv1alpha1.CRDBackupStatus{
+		BackupReference: ref,
+		Instances:       int32(len(snapshot.Instances)),
+		Time:            metav1.Now(),
+	}
+	// Only recorded backups can be restored, so the reference must not get lost if the reconciliation is
+	// interrupted after the instances or the CRD are deleted
+	return r.persistPolicyStatus(ctx, policy, log)
+}
+
+// readBackup reads and decodes a backup from the backup sink
+func (r *CRDCleanupPolicyReconciler) readBackup(ctx context.Context, ref policiesv1alpha1.BackupReference, log logr.Logger) (*backup.Backup, error) {
+	data, err := r.Backups.Read(ctx, ref)
+	if err != nil {
+		log.Error(err, "Failed to read the previous backup", "Sink", ref.Sink, "Location", ref.Location)
+		return nil, err
+	}
+	previous, err := backup.Decode(data)
+	if err != nil {
+		log.Error(err, "Failed to decode the previous backup", "Sink", ref.Sink, "Location", ref.Location)
+		return nil, err
+	}
+	return previous, nil
+}
+
+// mergeBackups adds the instances of the previous backup that no longer exist to the snapshot. It returns false
+// if the previous backup already contains every instance of the snapshot as it is, so no new backup is needed.
+func mergeBackups(snapshot, previous *backup.Backup) bool {
+	previousInstances := map[string][]byte{}
+	for _, instance := range previous.Instances {
+		// Instances are compared in their serialized form, since decoded backups represent numbers as floats
+		data, _ := instance.MarshalJSON()
+		previousInstances[instanceKey(&instance)] = data
+	}
+
+	changed := false
+	for _, instance := range snapshot.Instances {
+		key := instanceKey(&instance)
+		data, _ := instance.MarshalJSON()
+		if previousData, ok := previousInstances[key]; !ok || !bytes.Equal(data, previousData) {
+			changed = true
+		}
+		delete(previousInstances, key)
+	}
+	for _, instance := range previous.Instances {
+		if _, ok := previousInstances[instanceKey(&instance)]; ok {
+			snapshot.Instances = append(snapshot.Instances, instance)
+		}
+	}
+	return changed
+}
+
+// instanceKey identifies an instance within a backup
+func instanceKey(instance *unstructured.Unstructured) string {
+	return instance.GetNamespace() + "/" + instance.GetName()
+}
+
+// persistPolicyStatus writes the status of the policy without refreshing the policy from the response, so the
+// entries being processed are left as they are
+func (r *CRDCleanupPolicyReconciler) persistPolicyStatus(ctx context.Context, policy cleanupPolicy, log logr.Logger) error {
+	persisted := policy.DeepCopyObject().(cleanupPolicy)
+	if err := r.Status().Update(ctx, persisted); err != nil {
+		log.Error(err, "Failed to update CRDCleanupPolicy status", "policy", policy)
+		return err
+	}
+	policy.SetResourceVersion(persisted.GetResourceVersion())
+	return nil
+}
+
+// snapshot returns the CRD and all of its instances without their server-managed fields
+func (r *CRDCleanupPolicyReconciler) snapshot(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger) (*backup.Backup, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+	if err != nil {
+		return nil, err
+	}
+	crdObj := &unstructured.Unstructured{Object: content}
+	crdObj.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	backup.Clean(crdObj)
+	snapshot := &backup.Backup{CRD: crdObj, Instances: []unstructured.Unstructured{}}
+
+	mapping, err := r.instanceMapping(crd, "")
+	if err != nil {
+		log.Error(err, "Failed to resolve instances of CRD", "CRD", crd.Name)
+		return nil, err
+	}
+	instances := &unstructured.UnstructuredList{}
+	instances.SetGroupVersionKind(mapping.GroupVersionKind.GroupVersion().WithKind(mapping.GroupVersionKind.Kind + "List"))
+	for {
+		if err := r.apiReader().List(ctx, instances, client.Limit(instanceListPageSize), client.Continue(instances.GetContinue())); err != nil {
+			log.Error(err, "Failed to list instances of CRD for the backup", "CRD", crd.Name)
+			return nil, err
+		}
+		for _, instance := range instances.Items {
+			backup.Clean(&instance)
+			snapshot.Instances = append(snapshot.Instances, instance)
+		}
+		if instances.GetContinue() == "" {
+			return snapshot, nil
+		}
+	}
+}
+
+// backupName returns a unique name for the backup of the entry
+func backupName(entry *policiesv1alpha1.CRDCleanupEntryStatus) string {
+	name := strings.ReplaceAll(entryKey(entry), "/", "-")
+	if len(name) > maxBackupNameLength {
+		name = name[:maxBackupNameLength]
+	}
+	// Backups of the same entry taken within one second must not collide
+	return fmt.Sprintf("%s-%d-%s", name, time.Now().Unix(), rand.String(5))
+}
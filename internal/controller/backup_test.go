@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/backup"
+)
+
+var _ = Describe("Backups", func() {
+	Context("When backups are configured", func() {
+		const resourceName = "backup-policy"
+		const crdName = "keepsakes.backup.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a CRD and one instance of it")
+			Expect(k8sClient.Create(ctx, newTestCRD("backup.example.com", "keepsakes", "Keepsake", "v1"))).To(Succeed())
+
+			instance := &unstructured.Unstructured{}
+			instance.SetAPIVersion("backup.example.com/v1")
+			instance.SetKind("Keepsake")
+			instance.SetName("keepsake")
+			instance.SetNamespace("default")
+			instance.SetLabels(map[string]string{"team": "archive"})
+			instance.Object["spec"] = map[string]interface{}{"color": "blue"}
+			Eventually(func() error {
+				return k8sClient.Create(ctx, instance)
+			}).Should(Succeed())
+
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					InstancePolicy: policiesv1alpha1.InstancePolicyBackupThenDelete,
+					CRDsVersions:   []policiesv1alpha1.CRDCleanupVersion{{Name: crdName}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should back up the CRD and its instances before deleting them", func() {
+			sink := &backup.DirectorySink{Path: GinkgoT().TempDir()}
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client:  k8sClient,
+				Scheme:  k8sClient.Scheme(),
+				Backups: sink,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDraining))
+			Expect(policy.Status.Entries[0].Backup).NotTo(BeNil())
+			Expect(policy.Status.Entries[0].Backup.Instances).To(BeEquivalentTo(1))
+
+			By("reading the backup from the sink")
+			data, err := sink.Read(ctx, policy.Status.Entries[0].Backup.BackupReference)
+			Expect(err).NotTo(HaveOccurred())
+			snapshot, err := backup.Decode(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshot.CRD.GetName()).To(Equal(crdName))
+			Expect(snapshot.CRD.GetResourceVersion()).To(BeEmpty())
+			group, _, _ := unstructured.NestedString(snapshot.CRD.Object, "spec", "group")
+			Expect(group).To(Equal("backup.example.com"))
+			Expect(snapshot.Instances).To(HaveLen(1))
+			saved := snapshot.Instances[0]
+			Expect(saved.GroupVersionKind()).To(Equal(schema.GroupVersionKind{Group: "backup.example.com", Version: "v1", Kind: "Keepsake"}))
+			Expect(saved.GetNamespace()).To(Equal("default"))
+			Expect(saved.GetName()).To(Equal("keepsake"))
+			Expect(saved.GetLabels()).To(Equal(map[string]string{"team": "archive"}))
+			color, _, _ := unstructured.NestedString(saved.Object, "spec", "color")
+			Expect(color).To(Equal("blue"))
+			Expect(saved.GetUID()).To(BeEmpty())
+			Expect(saved.GetResourceVersion()).To(BeEmpty())
+			Expect(saved.GetManagedFields()).To(BeEmpty())
+
+			By("checking that the instance was deleted after it was backed up")
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "keepsake"}, &saved))
+			}).Should(BeTrue())
+
+			By("backing up an instance created after the first backup together with the deleted one")
+			late := &unstructured.Unstructured{}
+			late.SetAPIVersion("backup.example.com/v1")
+			late.SetKind("Keepsake")
+			late.SetName("late-keepsake")
+			late.SetNamespace("default")
+			Expect(k8sClient.Create(ctx, late)).To(Succeed())
+			first := policy.Status.Entries[0].Backup.BackupReference
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries[0].Backup.BackupReference).NotTo(Equal(first))
+			Expect(policy.Status.Entries[0].Backup.Instances).To(BeEquivalentTo(2))
+			data, err = sink.Read(ctx, policy.Status.Entries[0].Backup.BackupReference)
+			Expect(err).NotTo(HaveOccurred())
+			snapshot, err = backup.Decode(data)
+			Expect(err).NotTo(HaveOccurred())
+			names := []string{}
+			for _, instance := range snapshot.Instances {
+				names = append(names, instance.GetName())
+			}
+			Expect(names).To(ConsistOf("keepsake", "late-keepsake"))
+		})
+	})
+
+	Context("When merging a snapshot with the previous backup", func() {
+		// newInstance returns an instance as it is decoded from a backup or listed from the API server
+		newInstance := func(name string, replicas interface{}) unstructured.Unstructured {
+			instance := unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{"replicas": replicas}}}
+			instance.SetAPIVersion("backup.example.com/v1")
+			instance.SetKind("Keepsake")
+			instance.SetNamespace("default")
+			instance.SetName(name)
+			return instance
+		}
+
+		It("should not need a new backup if every instance is backed up as it is", func() {
+			previous := &backup.Backup{Instances: []unstructured.Unstructured{newInstance("a", float64(1)), newInstance("b", float64(1))}}
+			snapshot := &backup.Backup{Instances: []unstructured.Unstructured{newInstance("a", int64(1))}}
+			Expect(mergeBackups(snapshot, previous)).To(BeFalse())
+		})
+
+		It("should keep deleted instances when instances were created or changed", func() {
+			previous := &backup.Backup{Instances: []unstructured.Unstructured{newInstance("a", float64(1)), newInstance("b", float64(1))}}
+			snapshot := &backup.Backup{Instances: []unstructured.Unstructured{newInstance("a", int64(2)), newInstance("c", int64(1))}}
+			Expect(mergeBackups(snapshot, previous)).To(BeTrue())
+
+			replicas := map[string]int64{}
+			for _, instance := range snapshot.Instances {
+				value, _, _ := unstructured.NestedFieldNoCopy(instance.Object, "spec", "replicas")
+				switch v := value.(type) {
+				case int64:
+					replicas[instance.GetName()] = v
+				case float64:
+					replicas[instance.GetName()] = int64(v)
+				}
+			}
+			Expect(replicas).To(Equal(map[string]int64{"a": 2, "b": 1, "c": 1}))
+		})
+	})
+
+	It("should name backups of the same entry taken within one second differently", func() {
+		entry := &policiesv1alpha1.CRDCleanupEntryStatus{Name: "keepsakes.backup.example.com"}
+		Expect(backupName(entry)).NotTo(Equal(backupName(entry)))
+	})
+})
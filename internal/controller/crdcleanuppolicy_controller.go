@@ -23,32 +23,101 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/go-logr/logr"
 	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/backup"
 )
 
-// CRDCleanupPolicyReconciler reconciles a CRDCleanupPolicy object
+const (
+	// crdNameIndexField indexes CRDCleanupPolicies by the names of the CRDs they reference
+	crdNameIndexField = ".spec.crdsversions.name"
+
+	// retryPeriod is the interval in which pending and failed entries are retried
+	retryPeriod = time.Minute
+
+	// instanceListPageSize is the number of instances fetched per list request
+	instanceListPageSize = 500
+
+	// resyncPeriod is the interval in which policies are re-evaluated even if no watch event was received
+	resyncPeriod = 10 * time.Minute
+)
+
+// cleanupPolicy is a CRDCleanupPolicy or a ClusterCRDCleanupPolicy. Both are processed the same way.
+type cleanupPolicy interface {
+	client.Object
+	GetSpec() *policiesv1alpha1.CRDCleanupPolicySpec
+	GetStatus() *policiesv1alpha1.CRDCleanupPolicyStatus
+}
+
+// CRDCleanupPolicyReconciler reconciles CRDCleanupPolicy and ClusterCRDCleanupPolicy objects.
+// Requests without a namespace refer to a ClusterCRDCleanupPolicy.
 type CRDCleanupPolicyReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// APIReader reads directly from the API server. It is used to count instances without caching them.
+	// If it is nil, the Client is used.
+	APIReader client.Reader
+
+	// EnforceGrants denies entries of namespaced policies unless a CRDCleanupGrant allows
+	// the namespace of the policy to target the CRD
+	EnforceGrants bool
+
+	// AuthorizeRequester denies entries unless the user that created or last updated the policy, as recorded
+	// by the admission webhook, is allowed to delete the CRD or update it to remove a version
+	AuthorizeRequester bool
+
+	// Backups is the sink the CRDs and their instances are backed up to before they are removed.
+	// If it is nil, nothing is backed up.
+	Backups backup.Sink
+
+	// ProtectedCRDs are glob patterns of CRD names that are only removed if the entry acknowledges the protection
+	ProtectedCRDs []string
+
+	// EmergencyStop stops all deletions and updates of all policies while it is engaged. If it is nil, the
+	// operator can only be stopped per policy.
+	EmergencyStop *EmergencyStop
+
+	// instances watches the instances of CRDs that block an entry. It is nil if the reconciler runs without a manager.
+	instances *instanceWatcher
 }
 
 // +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=crdcleanuppolicies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=crdcleanuppolicies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=crdcleanuppolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=clustercrdcleanuppolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=clustercrdcleanuppolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=clustercrdcleanuppolicies/finalizers,verbs=update
+// +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=crdcleanupgrants,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=get;create
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=list;watch
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions/finalizers,verbs=update
-// +kubebuilder:rbac:groups="*",resources="*",verbs="list"
+// +kubebuilder:rbac:groups=storagemigration.k8s.io,resources=storageversionmigrations,verbs=get;create;delete
+// +kubebuilder:rbac:groups="*",resources="*",verbs=get;list;watch
+
+// The instances of CRDs are only deleted and migrated with the rights aggregated into the instance editor role,
+// see config/rbac/instance_editor_role.yaml
 
 func (r *CRDCleanupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
@@ -60,126 +129,452 @@ func (r *CRDCleanupPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 	if policy == nil {
-		return ctrl.Result{}, nil
+		// Stop watching the instances that blocked the deleted policy
+		return ctrl.Result{}, r.syncInstanceWatches(ctx, req.NamespacedName, nil)
 	}
-	// Initialize status fields if needed
-	r.initializeStatusFields(policy)
-
-	// Process CRDs
-	updatedRemainingCRDs, err := r.processCRDs(ctx, policy, log)
+	// Expand the selectors and sync the status entries with the spec and the selected CRDs if they changed
+	selected, err := r.selectEntries(ctx, policy, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	r.syncEntries(policy, selected, log)
+
+	// Process CRDs
+	blockingGVKs := r.processCRDs(ctx, policy, log)
 
 	// Update the status
-	err = r.updatePolicyStatus(ctx, policy, updatedRemainingCRDs, log)
+	err = r.updatePolicyStatus(ctx, policy, log)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// Watch the instances of blocked CRDs to get notified once they are gone
+	if err := r.syncInstanceWatches(ctx, req.NamespacedName, blockingGVKs); err != nil {
+		log.Error(err, "Failed to watch instances of blocked CRDs")
+		return ctrl.Result{}, err
+	}
+
 	// Requeue if there are still CRDs to process
-	if len(updatedRemainingCRDs) > 0 {
-		log.Info("Requeuing reconciliation as there are still CRDs to process")
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	if pending := countPendingEntries(policy); pending > 0 {
+		// If only the schedule holds the entries back, nothing is done before the next maintenance window opens
+		if next := nextWindowOpening(policy); next > 0 && countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseScheduled) == pending {
+			log.Info("Requeuing reconciliation for the next maintenance window", "RequeueAfter", next)
+			return ctrl.Result{RequeueAfter: next}, nil
+		}
+		requeueAfter := retryPeriod
+		if r.instances != nil && !hasEntriesToRetry(policy) {
+			// Blocked and terminating CRDs are handled by watches, so only resync occasionally
+			requeueAfter = resyncPeriod
+		}
+		// Entries waiting in a lifecycle stage, for their quiet period, for a maintenance window or for a new
+		// storage version to settle are processed
+		// as soon as it ends
+		if next := nextLifecycleTransition(policy); next > 0 && next < requeueAfter {
+			requeueAfter = next
+		}
+		if next := nextQuietPeriodEnd(policy); next > 0 && next < requeueAfter {
+			requeueAfter = next
+		}
+		if next := nextWindowOpening(policy); next > 0 && next < requeueAfter {
+			requeueAfter = next
+		}
+		if next := nextStorageVersionSettled(policy); next > 0 && next < requeueAfter {
+			requeueAfter = next
+		}
+		log.Info("Requeuing reconciliation as there are still CRDs to process", "RequeueAfter", requeueAfter)
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
 	log.Info("Reconciliation complete for CRDCleanupPolicy", "name", req.NamespacedName)
 	return ctrl.Result{}, nil
 }
 
-// fetchPolicy fetches the CRDCleanupPolicy object from the cluster
-func (r *CRDCleanupPolicyReconciler) fetchPolicy(ctx context.Context, req ctrl.Request, log logr.Logger) (*policiesv1alpha1.CRDCleanupPolicy, error) {
-	policy := &policiesv1alpha1.CRDCleanupPolicy{}
+// fetchPolicy fetches the CRDCleanupPolicy object from the cluster, or the ClusterCRDCleanupPolicy object
+// if the request has no namespace
+func (r *CRDCleanupPolicyReconciler) fetchPolicy(ctx context.Context, req ctrl.Request, log logr.Logger) (cleanupPolicy, error) {
+	var policy cleanupPolicy = &policiesv1alpha1.CRDCleanupPolicy{}
+	if req.Namespace == "" {
+		policy = &policiesv1alpha1.ClusterCRDCleanupPolicy{}
+	}
 	if err := r.Get(ctx, req.NamespacedName, policy); err != nil {
 		if errors.IsNotFound(err) {
-			log.Info("No CRDCleanupPolicy found. May be deleted", "name", policy.Name)
+			log.Info("No CRDCleanupPolicy found. May be deleted", "name", req.Name)
 			return nil, nil
 		}
 		log.Error(err, "Failed to fetch CRDCleanupPolicy")
 
 		return nil, err
 	}
-	log.Info("Fetched CRDCleanupPolicy", "name", policy.Name)
+	log.Info("Fetched CRDCleanupPolicy", "name", policy.GetName())
 	return policy, nil
 }
 
-// initializeStatusFields initializes the status fields of the CRDCleanupPolicy if they are nil
-func (r *CRDCleanupPolicyReconciler) initializeStatusFields(policy *policiesv1alpha1.CRDCleanupPolicy) {
-	if policy.Status.ProcessedCRDs == nil {
-		policy.Status.ProcessedCRDs = []string{}
+// syncEntries reconciles the status entries with the CRDs listed in the spec and the CRDs selected by the selectors
+// whenever the generation of the policy or the selected CRDs changed. Newly listed CRDs are added as pending,
+// entries removed from the spec are dropped and entries that were not found before are re-evaluated. A changed
+// version results in a new entry for the CRD. Selected CRDs and versions that have been deleted keep their entry.
+func (r *CRDCleanupPolicyReconciler) syncEntries(policy cleanupPolicy, selected []policiesv1alpha1.CRDCleanupEntryStatus, log logr.Logger) {
+	selectedCRDs := selectedKeys(selected)
+	if policy.GetStatus().Entries != nil && policy.GetGeneration() == policy.GetStatus().ObservedGeneration &&
+		slices.Equal(selectedCRDs, policy.GetStatus().SelectedCRDs) {
+		return
 	}
-	if policy.Status.NonExistentCRDs == nil {
-		policy.Status.NonExistentCRDs = []string{}
+	log.Info("Spec of CRDCleanupPolicy or selected CRDs changed, syncing entries", "Generation", policy.GetGeneration(), "ObservedGeneration", policy.GetStatus().ObservedGeneration)
+	policy.GetStatus().SelectedCRDs = selectedCRDs
+
+	existing := make(map[string]policiesv1alpha1.CRDCleanupEntryStatus, len(policy.GetStatus().Entries))
+	for _, entry := range policy.GetStatus().Entries {
+		existing[entryKey(&entry)] = entry
 	}
-	if policy.Status.RemainingCRDs == nil {
-		for _, crdVersion := range policy.Spec.CRDsVersions {
-			if crdVersion.Version == "" {
-				policy.Status.RemainingCRDs = append(policy.Status.RemainingCRDs, crdVersion.Name)
-				continue
+
+	entries := make([]policiesv1alpha1.CRDCleanupEntryStatus, 0, len(policy.GetSpec().CRDsVersions))
+	seen := make(map[string]bool, len(policy.GetSpec().CRDsVersions))
+	for _, crdVersion := range policy.GetSpec().CRDsVersions {
+		entry := policiesv1alpha1.CRDCleanupEntryStatus{
+			Name:    crdVersion.Name,
+			Version: crdVersion.Version,
+		}
+		key := entryKey(&entry)
+		// Ignore duplicate entries in the spec
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if previous, ok := existing[key]; ok {
+			entry = previous
+			if entry.Phase == policiesv1alpha1.CRDCleanupPhaseNotFound {
+				setEntryPhase(&entry, policiesv1alpha1.CRDCleanupPhasePending, "Re-evaluating after the policy changed", nil)
 			}
-			policy.Status.RemainingCRDs = append(policy.Status.RemainingCRDs, fmt.Sprintf("%s/%s", crdVersion.Name, crdVersion.Version))
+		} else {
+			log.Info("Adding CRD to policy status", "CRD", key)
+			setEntryPhase(&entry, policiesv1alpha1.CRDCleanupPhasePending, "Waiting to be processed", nil)
 		}
+		entries = append(entries, entry)
 	}
-}
 
-// processCRDs processes the CRDs listed in the policy and deletes them
-func (r *CRDCleanupPolicyReconciler) processCRDs(ctx context.Context, policy *policiesv1alpha1.CRDCleanupPolicy, log logr.Logger) ([]string, error) {
-	updatedRemainingCRDs := make([]string, 0)
+	for _, entry := range selected {
+		key := entryKey(&entry)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
 
-	for _, originalCRDName := range policy.Status.RemainingCRDs {
-		log.Info("Processing CRD", "Name", originalCRDName)
-		crdVersion := ""
-		crdName := originalCRDName
-		if strings.Contains(originalCRDName, "/") {
-			// In case of specific api versions should be processed
-			parts := strings.SplitN(originalCRDName, "/", 2)
-			crdName = parts[0]
-			crdVersion = parts[1]
+		if previous, ok := existing[key]; ok {
+			entry = previous
+			entry.Selected = true
+		} else {
+			log.Info("Adding selected CRD to policy status", "CRD", key)
+			setEntryPhase(&entry, policiesv1alpha1.CRDCleanupPhasePending, "Waiting to be processed", nil)
 		}
+		entries = append(entries, entry)
+	}
 
-		// Fetch the CRD definition to get its group, version, and kind
-		crd, err := r.fetchCRDDefinition(ctx, crdName, log)
-		if err != nil {
-			updatedRemainingCRDs = append(updatedRemainingCRDs, originalCRDName)
+	for _, entry := range policy.GetStatus().Entries {
+		key := entryKey(&entry)
+		if seen[key] {
 			continue
 		}
-
-		// Continue since the CRD must be deleted
-		if crd == nil {
-			policy.Status.NonExistentCRDs = append(policy.Status.NonExistentCRDs, originalCRDName)
+		if entry.Selected && entry.Phase == policiesv1alpha1.CRDCleanupPhaseDeleted && hasSelectors(policy) {
+			entries = append(entries, entry)
 			continue
 		}
+		log.Info("Removing CRD from policy status since it is no longer listed in the spec", "CRD", key)
+	}
 
-		// Check if there are any instances of this CRD in the cluster
-		instanceCount, err := r.checkCRDInstances(ctx, crd, log, crdVersion, originalCRDName, policy)
-		if err != nil {
-			updatedRemainingCRDs = append(updatedRemainingCRDs, originalCRDName)
-			continue
+	policy.GetStatus().Entries = entries
+}
+
+// processCRDs processes the CRDs listed in the policy and deletes them.
+// In DryRun mode the deletion is only simulated and the entries are moved to the Planned phase.
+// It returns the GVKs of all instances that block an entry.
+func (r *CRDCleanupPolicyReconciler) processCRDs(ctx context.Context, policy cleanupPolicy, log logr.Logger) []schema.GroupVersionKind {
+	blockingGVKs := []schema.GroupVersionKind{}
+	if user, _ := requester(policy); user != "" {
+		log = log.WithValues("RequestedBy", user)
+	}
+
+	// ClusterCRDCleanupPolicies take precedence over namespaced policies listing the same CRDs and namespaced
+	// policies may only target the CRDs their namespace is granted access to
+	superseding := map[string]string{}
+	var grants []policiesv1alpha1.CRDCleanupGrant
+	if namespace := policy.GetNamespace(); namespace != "" {
+		var err error
+		if superseding, err = r.clusterPolicyNamesByCRD(ctx, log); err != nil {
+			failPendingEntries(policy, "Failed to list ClusterCRDCleanupPolicies", err)
+			return blockingGVKs
 		}
+		if r.EnforceGrants {
+			if grants, err = r.namespaceGrants(ctx, namespace, log); err != nil {
+				failPendingEntries(policy, "Failed to list CRDCleanupGrants", err)
+				return blockingGVKs
+			}
+		}
+	}
 
-		// If there are instances, skip deletion
-		if instanceCount > 0 {
-			log.Info("Instances of CRD found, skipping deletion", "CRD", originalCRDName)
-			updatedRemainingCRDs = append(updatedRemainingCRDs, originalCRDName)
+	for i := range policy.GetStatus().Entries {
+		entry := &policy.GetStatus().Entries[i]
+		if isTerminalPhase(entry.Phase) {
 			continue
 		}
-
-		if instanceCount < 0 {
-			policy.Status.NonExistentCRDs = append(policy.Status.NonExistentCRDs, originalCRDName)
+		if clusterPolicy, ok := superseding[entry.Name]; ok {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseSuperseded, fmt.Sprintf("The CRD is managed by ClusterCRDCleanupPolicy %s", clusterPolicy), nil)
 			continue
 		}
-		// Attempt to delete the CRD
-		if err := r.deleteCRDorVersion(ctx, crd, log, crdVersion); err != nil {
-			updatedRemainingCRDs = append(updatedRemainingCRDs, originalCRDName)
+		if !acknowledgeProtection(policy, entry, r.ProtectedCRDs, log) {
 			continue
 		}
+		if r.EnforceGrants && policy.GetNamespace() != "" && grantFor(grants, entry.Name) == "" {
+			log.Info("No CRDCleanupGrant allows the namespace of the policy to target the CRD", "CRD", entry.Name, "Namespace", policy.GetNamespace())
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDenied, fmt.Sprintf("No CRDCleanupGrant allows namespace %s to target the CRD", policy.GetNamespace()), nil)
+			continue
+		}
+		if r.AuthorizeRequester {
+			message, err := r.authorizeRequester(ctx, policy, entry, log)
+			if err != nil {
+				setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, "Failed to review the access of the requester", err)
+				continue
+			}
+			if message != "" {
+				setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDenied, message, nil)
+				continue
+			}
+		}
+		if gvk := r.processEntry(ctx, policy, entry, log); gvk != nil {
+			blockingGVKs = append(blockingGVKs, *gvk)
+		}
+	}
+	return blockingGVKs
+}
 
-		// Add to processed CRDs
-		log.Info("Successfully deleted CRD", "CRD", originalCRDName)
-		policy.Status.ProcessedCRDs = append(policy.Status.ProcessedCRDs, originalCRDName)
+// failPendingEntries moves all entries that still have to be processed to the Failed phase
+func failPendingEntries(policy cleanupPolicy, message string, err error) {
+	for i := range policy.GetStatus().Entries {
+		if entry := &policy.GetStatus().Entries[i]; !isTerminalPhase(entry.Phase) {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, message, err)
+		}
+	}
+}
+
+// clusterPolicyNamesByCRD returns the name of a ClusterCRDCleanupPolicy for every CRD listed in the spec of one
+// or selected by one
+func (r *CRDCleanupPolicyReconciler) clusterPolicyNamesByCRD(ctx context.Context, log logr.Logger) (map[string]string, error) {
+	clusterPolicies := &policiesv1alpha1.ClusterCRDCleanupPolicyList{}
+	if err := r.List(ctx, clusterPolicies); err != nil {
+		log.Error(err, "Failed to list ClusterCRDCleanupPolicies")
+		return nil, err
 	}
 
-	return updatedRemainingCRDs, nil
+	names := map[string]string{}
+	for _, clusterPolicy := range clusterPolicies.Items {
+		if clusterPolicy.DeletionTimestamp != nil {
+			continue
+		}
+		for _, crdVersion := range clusterPolicy.Spec.CRDsVersions {
+			if _, ok := names[crdVersion.Name]; !ok {
+				names[crdVersion.Name] = clusterPolicy.Name
+			}
+		}
+		for _, entry := range clusterPolicy.Status.Entries {
+			if _, ok := names[entry.Name]; !ok && entry.Selected {
+				names[entry.Name] = clusterPolicy.Name
+			}
+		}
+	}
+	return names, nil
+}
+
+// processEntry evaluates a single entry of the policy and deletes the CRD or version if possible.
+// If the entry is blocked by instances of the CRD or waits for them to be deleted, the GVK of the instances is returned.
+func (r *CRDCleanupPolicyReconciler) processEntry(ctx context.Context, policy cleanupPolicy, entry *policiesv1alpha1.CRDCleanupEntryStatus, log logr.Logger) *schema.GroupVersionKind {
+	dryRun := isDryRun(policy)
+	entryName := entryKey(entry)
+	log.Info("Processing CRD", "Name", entryName)
+
+	// Fetch the CRD definition to get its group, version, and kind
+	crd, err := r.fetchCRDDefinition(ctx, entry.Name, log)
+	if err != nil {
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, "Failed to fetch the CRD", err)
+		return nil
+	}
+
+	// A CRD that disappears while terminating has been deleted by the operator
+	if crd == nil {
+		if entry.Phase == policiesv1alpha1.CRDCleanupPhaseDeleting {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDeleted, "The CRD has been deleted", nil)
+			return nil
+		}
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseNotFound, "The CRD does not exist", nil)
+		return nil
+	}
+
+	if entry.Version == "" && crd.DeletionTimestamp != nil {
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDeleting, "The CRD is terminating", nil)
+		return nil
+	}
+
+	// Check if there are any instances of this CRD in the cluster
+	instanceCount, err := r.checkCRDInstances(ctx, crd, log, entry.Version)
+	if err != nil {
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, "Failed to list instances of the CRD", err)
+		return nil
+	}
+
+	if instanceCount < 0 {
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseNotFound, fmt.Sprintf("The CRD has no version %s", entry.Version), nil)
+		return nil
+	}
+	entry.InstanceCount = int32(instanceCount)
+	entry.BlockedVersion = ""
+
+	if entry.Version != "" {
+		// Objects stored in any other version are migrated to the storage version before the version is removed
+		successor := storageSuccessor(crd, entry.Version, requestedStorageSuccessor(policy, entry))
+		if message := versionRemovalBlocker(crd, entry.Version, successor); message != "" {
+			log.Info("Version of CRD cannot be removed, skipping removal", "CRD", entryName, "StoredVersions", crd.Status.StoredVersions)
+			entry.BlockedVersion = entry.Version
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseBlocked, message, nil)
+			return nil
+		}
+
+		// Deprecate and unserve the version for the configured periods before it is removed
+		if lifecycle := policy.GetSpec().VersionLifecycle; lifecycle != nil {
+			if hold := r.holdFor(ctx, policy); hold != nil && !lifecycleCompleted(entry, lifecycle) {
+				// Versions waiting in a stage keep their phase, only the next stage is deferred
+				if lifecycleStageDue(entry, lifecycle) {
+					hold.apply(entry, "the version enters the next stage of its lifecycle afterwards")
+				}
+				return nil
+			}
+			ready, err := r.advanceVersionLifecycle(ctx, crd, log, entry, lifecycle, dryRun)
+			if err != nil {
+				setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, "Failed to update the lifecycle of the version", err)
+				return nil
+			}
+			if !ready {
+				if dryRun {
+					setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhasePlanned, "The version would be deprecated and unserved before it is removed", nil)
+				}
+				return nil
+			}
+		}
+	} else if instanceCount > 0 {
+		// If there are instances, skip deletion until the instance policy removed them
+		log.Info("Instances of CRD found, skipping deletion", "CRD", entryName, "InstancePolicy", instancePolicyFor(policy, entry))
+		entry.UnusedSince = nil
+		if policy.GetSpec().UnusedCRDs != nil {
+			// Only unused CRDs are collected, so the instances of a CRD in use are never deleted by the instance policy
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseBlocked, fmt.Sprintf("The CRD is in use by %d instances", instanceCount), nil)
+		} else {
+			r.handleInstances(ctx, policy, crd, entry, instanceCount, log)
+		}
+		gvk := schema.GroupVersionKind{Group: crd.Spec.Group, Version: servedVersion(crd, entry.Version), Kind: crd.Spec.Names.Kind}
+		return &gvk
+	} else if collection := policy.GetSpec().UnusedCRDs; collection != nil && !quietPeriodEnded(entry, collection, log) {
+		// Unused CRDs are only deleted once they had no instances for the quiet period
+		deleteAt := quietPeriodEnd(entry, collection).Format(time.RFC3339)
+		if dryRun {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhasePlanned, fmt.Sprintf("The CRD would be deleted at %s if no instances are created", deleteAt), nil)
+		} else {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseUnused, fmt.Sprintf("The CRD has no instances and is deleted at %s", deleteAt), nil)
+		}
+		return nil
+	}
+
+	// Entries are evaluated at any time, but the CRD is only changed while the policy is not held
+	if hold := r.holdFor(ctx, policy); hold != nil {
+		if entry.Version != "" {
+			hold.apply(entry, "the version is removed afterwards")
+		} else {
+			hold.apply(entry, "the CRD is deleted afterwards")
+		}
+		return nil
+	}
+
+	// Hand the storage version over to its successor, the objects are migrated before the version is removed
+	if entry.Version != "" && entry.Version == storageVersion(crd) {
+		entry.Attempts++
+		successor := storageSuccessor(crd, entry.Version, requestedStorageSuccessor(policy, entry))
+		if err := r.handOverStorageVersion(ctx, crd, log, entry.Version, successor, dryRun); err != nil {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, "Failed to hand over the storage version", err)
+			return nil
+		}
+		if dryRun {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhasePlanned,
+				fmt.Sprintf("The storage version would be handed over to %s before the version is removed", successor), nil)
+			return nil
+		}
+		entry.Migration = &policiesv1alpha1.CRDMigrationStatus{
+			StorageVersion:         successor,
+			PreviousStorageVersion: entry.Version,
+			HandOverTime:           &metav1.Time{Time: time.Now()},
+		}
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseMigrating, fmt.Sprintf("Handed the storage version over to %s", successor), nil)
+		return nil
+	}
+
+	// API servers pick up a new storage version asynchronously, objects rewritten before would still be stored
+	// in the previous storage version
+	if migration := entry.Migration; migration != nil && migration.PreviousStorageVersion != "" && migration.StartTime == nil {
+		if migration.HandOverTime == nil {
+			migration.HandOverTime = &metav1.Time{Time: time.Now()}
+		}
+		if !storageVersionSettled(crd, migration, time.Now()) {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseMigrating,
+				fmt.Sprintf("Waiting for the API servers to store objects in version %s", migration.StorageVersion), nil)
+			return nil
+		}
+	}
+
+	// Back up the CRD and its instances before anything is removed
+	if !dryRun {
+		if err := r.backupEntry(ctx, policy, crd, entry, log); err != nil {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, "Failed to back up the CRD", err)
+			return nil
+		}
+	}
+
+	// Attempt to delete the CRD
+	entry.Attempts++
+	if err := r.deleteCRDorVersion(ctx, crd, log, entry, dryRun); err != nil {
+		message := "Failed to delete the CRD"
+		if entry.Migration != nil && entry.Migration.CompletionTime == nil {
+			if blockOnInstanceAccess(entry, crd, err) {
+				return nil
+			}
+			message = fmt.Sprintf("Failed to migrate stored objects to version %s", entry.Migration.StorageVersion)
+		}
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, message, err)
+		return nil
+	}
+
+	// Nothing has been deleted in DryRun mode, so the entry is only planned
+	if dryRun {
+		log.Info("CRD would be deleted", "CRD", entryName)
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhasePlanned, "The CRD would be deleted", nil)
+		return nil
+	}
+
+	if entry.Migration != nil && entry.Migration.CompletionTime == nil {
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseMigrating,
+			fmt.Sprintf("Migrating stored objects to version %s", entry.Migration.StorageVersion), nil)
+		return nil
+	}
+
+	if entry.Version != "" {
+		log.Info("Successfully removed version from CRD", "CRD", entryName)
+		if entry.Lifecycle != nil {
+			now := metav1.Now()
+			entry.Lifecycle.RemovedTime = &now
+		}
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDeleted, "The version has been removed from the CRD", nil)
+		return nil
+	}
+
+	// The CRD is only gone once the API server has removed all of its instances
+	log.Info("Successfully deleted CRD", "CRD", entryName)
+	setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDeleting, "The CRD is terminating", nil)
+	return nil
 }
 
 func (r *CRDCleanupPolicyReconciler) fetchCRDDefinition(ctx context.Context, crdName string, log logr.Logger) (*v1.CustomResourceDefinition, error) {
@@ -199,72 +594,198 @@ func (r *CRDCleanupPolicyReconciler) fetchCRDDefinition(ctx context.Context, crd
 	return crd, nil
 }
 
-func (r *CRDCleanupPolicyReconciler) checkCRDInstances(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion string, originalCRDName string, policy *policiesv1alpha1.CRDCleanupPolicy) (int, error) {
-	group := crd.Spec.Group
-	kind := crd.Spec.Names.Singular
-	version := crdVersion
-	if !slices.ContainsFunc(crd.Spec.Versions, func(v v1.CustomResourceDefinitionVersion) bool {
-		return crdVersion == "" || v.Name == crdVersion
+// checkCRDInstances returns the number of instances of the CRD in the cluster or -1 if the CRD has no such version.
+// The instances are resolved through the RESTMapper using the kind and plural of the CRD and are listed
+// across all namespaces for namespaced CRDs.
+func (r *CRDCleanupPolicyReconciler) checkCRDInstances(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion string) (int, error) {
+	if crdVersion != "" && !slices.ContainsFunc(crd.Spec.Versions, func(v v1.CustomResourceDefinitionVersion) bool {
+		return v.Name == crdVersion
 	}) {
 		log.Info("No version found in CRD", "CRD", crd.GetName(), "Version", crdVersion)
 		return -1, nil
 	}
-	if crdVersion == "" {
-		version = crd.Spec.Versions[0].Name
+
+	mapping, err := r.instanceMapping(crd, crdVersion)
+	if err != nil {
+		log.Error(err, "Failed to resolve instances of CRD", "CRD", crd.GetName())
+		return -1, err
+	}
+	log.Info("Checking for CRD instances", "CRD", crd.GetName(), "GVR", mapping.Resource.String(), "Scope", mapping.Scope.Name())
+
+	count := 0
+	instances := &metav1.PartialObjectMetadataList{}
+	instances.SetGroupVersionKind(mapping.GroupVersionKind.GroupVersion().WithKind(mapping.GroupVersionKind.Kind + "List"))
+	for {
+		if err := r.apiReader().List(ctx, instances, client.Limit(instanceListPageSize), client.Continue(instances.GetContinue())); err != nil {
+			if errors.IsNotFound(err) {
+				return count, nil
+			}
+			log.Error(err, "Failed to list instances of CRD", "CRD", crd.GetName())
+			return -1, err
+		}
+		count += len(instances.Items)
+		if instances.GetContinue() == "" {
+			return count, nil
+		}
 	}
-	log.Info("Checking for CRD instances", "CRD", crd.GetName(), "Version", version)
+}
 
-	crdGVR := schema.GroupVersionResource{
-		Group:    group,
-		Version:  version,
-		Resource: kind,
+// instanceMapping resolves the REST mapping of the instances of the CRD. The instances are looked up in the given
+// version if it is served, otherwise in the storage version or any other served version of the CRD.
+func (r *CRDCleanupPolicyReconciler) instanceMapping(crd *v1.CustomResourceDefinition, crdVersion string) (*meta.RESTMapping, error) {
+	version := servedVersion(crd, crdVersion)
+	if version == "" {
+		return nil, fmt.Errorf("CRD %s has no served version", crd.GetName())
 	}
 
-	var instances unstructured.UnstructuredList
-	instances.SetGroupVersionKind(crdGVR.GroupVersion().WithKind(kind))
-	if err := r.List(ctx, &instances); client.IgnoreNotFound(err) != nil {
-		log.Error(err, "Failed to list instances of CRD", "CRD", crd.GetName())
-		return -1, err
+	mapping, err := r.RESTMapper().RESTMapping(schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind}, version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Resource.Resource != crd.Spec.Names.Plural {
+		return nil, fmt.Errorf("CRD %s resolved to resource %s instead of %s", crd.GetName(), mapping.Resource.Resource, crd.Spec.Names.Plural)
 	}
-	return len(slices.DeleteFunc(instances.Items, func(item unstructured.Unstructured) bool {
-		return item.GetAPIVersion() != crdVersion && crdVersion != ""
-	})), nil
+	return mapping, nil
 }
 
-// deleteCRDorVersion deletes the given CRD or a specific apiVersion of the CRD from the cluster
-func (r *CRDCleanupPolicyReconciler) deleteCRDorVersion(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion string) error {
-	if crdVersion == "" {
+// apiReader returns the reader used to list instances of CRDs
+func (r *CRDCleanupPolicyReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// servedVersion returns the given version if it is served by the CRD, otherwise the storage version
+// if it is served or the first served version. It returns an empty string if no version is served.
+func servedVersion(crd *v1.CustomResourceDefinition, crdVersion string) string {
+	served := []string{}
+	for _, version := range crd.Spec.Versions {
+		if version.Served {
+			served = append(served, version.Name)
+		}
+	}
+	switch {
+	case len(served) == 0:
+		return ""
+	case slices.Contains(served, crdVersion):
+		return crdVersion
+	case slices.Contains(served, storageVersion(crd)):
+		return storageVersion(crd)
+	default:
+		return served[0]
+	}
+}
+
+// deleteCRDorVersion deletes the given CRD or the version of the entry from the cluster.
+// If dryRun is set, the request is sent with dryRun=All and nothing is persisted.
+func (r *CRDCleanupPolicyReconciler) deleteCRDorVersion(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, entry *policiesv1alpha1.CRDCleanupEntryStatus, dryRun bool) error {
+	if entry.Version == "" {
 		// Delete the entire CRD
-		log.Info("Delete the entire CRD since no specific apiVerson was specified", "CRD", crd.GetName())
-		return r.deleteCRD(ctx, crd, log)
+		log.Info("Delete the entire CRD since no specific apiVerson was specified", "CRD", crd.GetName(), "DryRun", dryRun)
+		return r.deleteCRD(ctx, crd, log, dryRun)
 	}
-	return r.deleteCRDVersion(ctx, crd, log, crdVersion)
+	return r.deleteCRDVersion(ctx, crd, log, entry, dryRun)
 }
 
-func (r *CRDCleanupPolicyReconciler) deleteCRD(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger) error {
-	if err := r.Delete(ctx, crd); err != nil {
-		log.Error(err, "Failed to delete CRD", "CRD", crd.GetName())
+func (r *CRDCleanupPolicyReconciler) deleteCRD(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, dryRun bool) error {
+	opts := []client.DeleteOption{}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.Delete(ctx, crd, opts...); err != nil {
+		log.Error(err, "Failed to delete CRD", "CRD", crd.GetName(), "DryRun", dryRun)
 		return err
 	}
-	log.Info("Successfully deleted CRD", "CRD", crd.GetName())
+	log.Info("Successfully deleted CRD", "CRD", crd.GetName(), "DryRun", dryRun)
 	return nil
 }
 
-func (r *CRDCleanupPolicyReconciler) deleteCRDVersion(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion string) error {
-	// Remove the specific version from the CRD
+// deleteCRDVersion removes the version of the entry from the CRD. If objects may still be stored in the version,
+// they are migrated to the storage version first and the version is removed from the stored versions of the CRD.
+func (r *CRDCleanupPolicyReconciler) deleteCRDVersion(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, entry *policiesv1alpha1.CRDCleanupEntryStatus, dryRun bool) error {
+	crdVersion := entry.Version
+	if slices.Contains(crd.Status.StoredVersions, crdVersion) {
+		if entry.Migration == nil {
+			entry.Migration = &policiesv1alpha1.CRDMigrationStatus{}
+		}
+		if err := r.migrateStoredVersion(ctx, crd, log, crdVersion, entry.Migration, dryRun); err != nil {
+			return err
+		}
+		// The API server rejects removing a version that is still stored, which it still is after a dry run
+		if dryRun {
+			log.Info("Version would be removed from CRD after the migration", "CRD", crd.GetName(), "Version", crdVersion)
+			return nil
+		}
+		if entry.Migration.CompletionTime == nil {
+			log.Info("Waiting for the migration before removing the version from CRD", "CRD", crd.GetName(), "Version", crdVersion)
+			return nil
+		}
+	}
+
+	// Remove the specific version from the CRD, but never send a CRD without versions or without a storage version
 	newVersions := filterVersions(crd.Spec.Versions, crdVersion)
+	if len(newVersions) == 0 || !slices.ContainsFunc(newVersions, func(v v1.CustomResourceDefinitionVersion) bool { return v.Storage }) {
+		return fmt.Errorf("removing version %s would leave CRD %s without a storage version", crdVersion, crd.GetName())
+	}
 	crd.Spec.Versions = newVersions
 
-	if err := r.Update(ctx, crd); err != nil {
-		log.Error(err, "Failed to update CRD", "CRD", crd.GetName(), "Version", crdVersion)
+	opts := []client.UpdateOption{}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.Update(ctx, crd, opts...); err != nil {
+		log.Error(err, "Failed to update CRD", "CRD", crd.GetName(), "Version", crdVersion, "DryRun", dryRun)
 		return err
 	}
 
-	log.Info("Successfully removed version from CRD", "CRD", crd.GetName(), "Version", crdVersion)
+	log.Info("Successfully removed version from CRD", "CRD", crd.GetName(), "Version", crdVersion, "DryRun", dryRun)
 
 	return nil
 }
 
+// versionRemovalBlocker returns a message explaining why the version cannot be removed from the CRD,
+// or an empty string if it can be removed. Objects stored in a version other than the storage version
+// are migrated before the version is removed and the storage version is handed over to the successor first,
+// so only the last version or a storage version without a successor block the removal.
+func versionRemovalBlocker(crd *v1.CustomResourceDefinition, crdVersion, successor string) string {
+	if len(crd.Spec.Versions) == 1 {
+		return fmt.Sprintf("Version %s is the last version of the CRD", crdVersion)
+	}
+	if storageVersion(crd) == crdVersion && successor == "" {
+		return fmt.Sprintf("Version %s is the storage version of the CRD and no successor is available", crdVersion)
+	}
+	return ""
+}
+
+// specEntry returns the entry in the spec of the policy the status entry was created for
+func specEntry(policy cleanupPolicy, entry *policiesv1alpha1.CRDCleanupEntryStatus) *policiesv1alpha1.CRDCleanupVersion {
+	for i, crdVersion := range policy.GetSpec().CRDsVersions {
+		if crdVersion.Name == entry.Name && crdVersion.Version == entry.Version {
+			return &policy.GetSpec().CRDsVersions[i]
+		}
+	}
+	return nil
+}
+
+// requestedStorageSuccessor returns the storage successor requested for the entry in the spec of the policy
+func requestedStorageSuccessor(policy cleanupPolicy, entry *policiesv1alpha1.CRDCleanupEntryStatus) string {
+	if crdVersion := specEntry(policy, entry); crdVersion != nil {
+		return crdVersion.StorageSuccessor
+	}
+	return ""
+}
+
+// storageVersion returns the name of the version the CRD is stored in
+func storageVersion(crd *v1.CustomResourceDefinition) string {
+	for _, version := range crd.Spec.Versions {
+		if version.Storage {
+			return version.Name
+		}
+	}
+	return crd.Spec.Versions[0].Name
+}
+
 func filterVersions(versions []v1.CustomResourceDefinitionVersion, crdVersion string) []v1.CustomResourceDefinitionVersion {
 	newVersions := []v1.CustomResourceDefinitionVersion{}
 	for _, version := range versions {
@@ -275,18 +796,107 @@ func filterVersions(versions []v1.CustomResourceDefinitionVersion, crdVersion st
 	return newVersions
 }
 
+// isDryRun returns true if the policy should only plan the deletion of its CRDs
+func isDryRun(policy cleanupPolicy) bool {
+	return policy.GetSpec().Mode == policiesv1alpha1.CleanupModeDryRun
+}
+
+// isTerminalPhase returns true if an entry in the given phase does not need to be processed anymore
+func isTerminalPhase(phase policiesv1alpha1.CRDCleanupPhase) bool {
+	return phase == policiesv1alpha1.CRDCleanupPhaseDeleted || phase == policiesv1alpha1.CRDCleanupPhaseNotFound
+}
+
+// isPendingPhase returns true if an entry in the given phase still has to be processed by the policy.
+// Superseded entries are processed by a ClusterCRDCleanupPolicy instead. Planned entries are final in DryRun
+// mode, they are only evaluated again when the policy, the CRD or its instances change.
+func isPendingPhase(phase policiesv1alpha1.CRDCleanupPhase, dryRun bool) bool {
+	if dryRun && phase == policiesv1alpha1.CRDCleanupPhasePlanned {
+		return false
+	}
+	return !isTerminalPhase(phase) && phase != policiesv1alpha1.CRDCleanupPhaseSuperseded
+}
+
+// entryKey returns the name of an entry in the form "name" or "name/version"
+func entryKey(entry *policiesv1alpha1.CRDCleanupEntryStatus) string {
+	if entry.Version == "" {
+		return entry.Name
+	}
+	return fmt.Sprintf("%s/%s", entry.Name, entry.Version)
+}
+
+// setEntryPhase sets the phase, message and last error of an entry and bumps the transition time if the phase changed
+func setEntryPhase(entry *policiesv1alpha1.CRDCleanupEntryStatus, phase policiesv1alpha1.CRDCleanupPhase, message string, err error) {
+	if entry.Phase != phase {
+		entry.Phase = phase
+		entry.LastTransitionTime = metav1.Now()
+	}
+	entry.Message = message
+	entry.LastError = ""
+	if err != nil {
+		entry.LastError = err.Error()
+	}
+}
+
+// countPendingEntries returns the number of entries that still need to be processed
+func countPendingEntries(policy cleanupPolicy) int {
+	pending := 0
+	for _, entry := range policy.GetStatus().Entries {
+		if isPendingPhase(entry.Phase, isDryRun(policy)) {
+			pending++
+		}
+	}
+	return pending
+}
+
+// hasEntriesToRetry returns true if any entry is pending, migrating, failed or blocked by an error and has to be
+// retried periodically. Blocked entries only report an error if the operator is missing rights, which are granted
+// without any event the operator watches.
+func hasEntriesToRetry(policy cleanupPolicy) bool {
+	return countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhasePending) > 0 ||
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseMigrating) > 0 ||
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseFailed) > 0 ||
+		slices.ContainsFunc(policy.GetStatus().Entries, func(entry policiesv1alpha1.CRDCleanupEntryStatus) bool {
+			return entry.Phase == policiesv1alpha1.CRDCleanupPhaseBlocked && entry.LastError != ""
+		})
+}
+
+// countEntriesInPhase returns the number of entries in the given phase
+func countEntriesInPhase(policy cleanupPolicy, phase policiesv1alpha1.CRDCleanupPhase) int {
+	count := 0
+	for _, entry := range policy.GetStatus().Entries {
+		if entry.Phase == phase {
+			count++
+		}
+	}
+	return count
+}
+
 // updatePolicyStatus updates the status of the CRDCleanupPolicy
-func (r *CRDCleanupPolicyReconciler) updatePolicyStatus(ctx context.Context, policy *policiesv1alpha1.CRDCleanupPolicy, updatedRemainingCRDs []string, log logr.Logger) error {
-	policy.Status.RemainingCRDs = updatedRemainingCRDs
+func (r *CRDCleanupPolicyReconciler) updatePolicyStatus(ctx context.Context, policy cleanupPolicy, log logr.Logger) error {
+	pending := countPendingEntries(policy)
+	suspended := r.suspendedCondition(ctx, policy)
 
-	if len(updatedRemainingCRDs) == 0 {
-		policy.Status.StatusMessage = "All CRDs have been successfully processed."
+	if suspended.Status == metav1.ConditionTrue && pending > 0 {
+		policy.GetStatus().StatusMessage = fmt.Sprintf("%s, %d CRDs are pending.", suspended.Message, pending)
+		log.Info("Changes are suspended", "Reason", suspended.Reason, "RemainingCRDsCount", pending)
+	} else if isDryRun(policy) {
+		planned := countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhasePlanned)
+		policy.GetStatus().StatusMessage = fmt.Sprintf("Dry run: %d of %d remaining CRDs would be deleted.", planned, planned+pending)
+		log.Info("Dry run completed", "PlannedCRDsCount", planned)
+	} else if pending == 0 {
+		policy.GetStatus().StatusMessage = "All CRDs have been successfully processed."
 		log.Info("All CRDs processed successfully")
 	} else {
-		policy.Status.StatusMessage = "Some CRDs are still pending deletion."
-		log.Info("Some CRDs are still pending deletion", "RemainingCRDsCount", len(updatedRemainingCRDs))
+		policy.GetStatus().StatusMessage = "Some CRDs are still pending deletion."
+		log.Info("Some CRDs are still pending deletion", "RemainingCRDsCount", pending)
 	}
 
+	total := len(policy.GetStatus().Entries)
+	policy.GetStatus().Progress = fmt.Sprintf("%d/%d", total-pending, total)
+	policy.GetStatus().ObservedGeneration = policy.GetGeneration()
+	setPolicyConditions(policy)
+	meta.SetStatusCondition(&policy.GetStatus().Conditions, suspended)
+
 	if err := r.Status().Update(ctx, policy); err != nil {
 		log.Error(err, "Failed to update CRDCleanupPolicy status", "policy", policy)
 		return err
@@ -295,9 +905,183 @@ func (r *CRDCleanupPolicyReconciler) updatePolicyStatus(ctx context.Context, pol
 	return nil
 }
 
+// setPolicyConditions derives the Ready, Progressing, Blocked and Degraded conditions from the entries of the policy
+// and the InMaintenanceWindow condition from its schedule
+func setPolicyConditions(policy cleanupPolicy) {
+	pending := countPendingEntries(policy)
+	blocked := countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseBlocked) +
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseDenied)
+	failed := countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseFailed)
+	deleting := countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseDeleting) +
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseUnused) +
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseDraining) +
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseDeprecated) +
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseUnserved) +
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseMigrating) +
+		countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhasePending)
+
+	ready := metav1.Condition{Type: policiesv1alpha1.ConditionTypeReady}
+	switch {
+	case isDryRun(policy) && failed == 0:
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = "DryRunCompleted"
+		ready.Message = fmt.Sprintf("%d entries would be deleted", countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhasePlanned))
+	case pending == 0:
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = "AllEntriesProcessed"
+		ready.Message = "All CRDs have been processed"
+	default:
+		ready.Status = metav1.ConditionFalse
+		ready.Reason = "EntriesPending"
+		ready.Message = fmt.Sprintf("%d entries are still pending", pending)
+	}
+
+	progressing := metav1.Condition{Type: policiesv1alpha1.ConditionTypeProgressing}
+	if deleting > 0 && !isDryRun(policy) {
+		progressing.Status = metav1.ConditionTrue
+		progressing.Reason = "Deleting"
+		progressing.Message = fmt.Sprintf("%d entries are being processed", deleting)
+	} else {
+		progressing.Status = metav1.ConditionFalse
+		progressing.Reason = "Idle"
+		progressing.Message = "No entries are being deleted"
+	}
+
+	blockedCondition := metav1.Condition{Type: policiesv1alpha1.ConditionTypeBlocked}
+	if blocked > 0 {
+		blockedCondition.Status = metav1.ConditionTrue
+		blockedCondition.Reason = "EntriesBlocked"
+		blockedKeys := append(entryKeysInPhase(policy, policiesv1alpha1.CRDCleanupPhaseBlocked), entryKeysInPhase(policy, policiesv1alpha1.CRDCleanupPhaseDenied)...)
+		blockedCondition.Message = fmt.Sprintf("%d entries are blocked: %s", blocked, strings.Join(blockedKeys, ", "))
+	} else {
+		blockedCondition.Status = metav1.ConditionFalse
+		blockedCondition.Reason = "NotBlocked"
+		blockedCondition.Message = "No entries are blocked"
+	}
+
+	degraded := metav1.Condition{Type: policiesv1alpha1.ConditionTypeDegraded}
+	if failed > 0 {
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = "ProcessingFailed"
+		degraded.Message = fmt.Sprintf("%d entries failed: %s", failed, strings.Join(entryKeysInPhase(policy, policiesv1alpha1.CRDCleanupPhaseFailed), ", "))
+	} else {
+		degraded.Status = metav1.ConditionFalse
+		degraded.Reason = "NoFailures"
+		degraded.Message = "No entries failed"
+	}
+
+	for _, condition := range []metav1.Condition{ready, progressing, blockedCondition, degraded, maintenanceWindowCondition(policy)} {
+		condition.ObservedGeneration = policy.GetGeneration()
+		meta.SetStatusCondition(&policy.GetStatus().Conditions, condition)
+	}
+}
+
+// entryKeysInPhase returns the names of all entries in the given phase
+func entryKeysInPhase(policy cleanupPolicy, phase policiesv1alpha1.CRDCleanupPhase) []string {
+	keys := []string{}
+	for i := range policy.GetStatus().Entries {
+		if policy.GetStatus().Entries[i].Phase == phase {
+			keys = append(keys, entryKey(&policy.GetStatus().Entries[i]))
+		}
+	}
+	return keys
+}
+
+// syncInstanceWatches watches the instances of the given GVKs for the policy and stops all other watches of the policy
+func (r *CRDCleanupPolicyReconciler) syncInstanceWatches(ctx context.Context, policy types.NamespacedName, gvks []schema.GroupVersionKind) error {
+	if r.instances == nil {
+		return nil
+	}
+	return r.instances.Sync(ctx, policy, gvks)
+}
+
+// findPoliciesForCRD returns a reconcile request for every policy and cluster policy that references the given CRD
+func (r *CRDCleanupPolicyReconciler) findPoliciesForCRD(ctx context.Context, crd client.Object) []reconcile.Request {
+	requests := r.findPoliciesForCRDNames(ctx, crd.GetName())
+	// Policies with selectors may select the CRD
+	for _, request := range r.findPoliciesForCRDNames(ctx, selectorIndexValue) {
+		if !slices.Contains(requests, request) {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
+
+// findPoliciesForClusterPolicy returns a reconcile request for the given cluster policy and every namespaced
+// policy that references one of its CRDs, since the cluster policy supersedes their entries
+func (r *CRDCleanupPolicyReconciler) findPoliciesForClusterPolicy(ctx context.Context, obj client.Object) []reconcile.Request {
+	requests := []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(obj)}}
+	for _, name := range indexCRDNames(obj) {
+		for _, request := range r.findPoliciesForCRDNames(ctx, name) {
+			if request.Namespace != "" && !slices.Contains(requests, request) {
+				requests = append(requests, request)
+			}
+		}
+	}
+	return requests
+}
+
+// findPoliciesForCRDNames returns a reconcile request for every policy and cluster policy that references the CRD
+func (r *CRDCleanupPolicyReconciler) findPoliciesForCRDNames(ctx context.Context, crdName string) []reconcile.Request {
+	policies := &policiesv1alpha1.CRDCleanupPolicyList{}
+	if err := r.List(ctx, policies, client.MatchingFields{crdNameIndexField: crdName}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list CRDCleanupPolicies for CRD", "CRD", crdName)
+		return nil
+	}
+	clusterPolicies := &policiesv1alpha1.ClusterCRDCleanupPolicyList{}
+	if err := r.List(ctx, clusterPolicies, client.MatchingFields{crdNameIndexField: crdName}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ClusterCRDCleanupPolicies for CRD", "CRD", crdName)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(policies.Items)+len(clusterPolicies.Items))
+	for _, policy := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policy)})
+	}
+	for _, policy := range clusterPolicies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policy)})
+	}
+	return requests
+}
+
+// indexCRDNames returns the names of all CRDs referenced by a policy, either in its spec or its status,
+// and selectorIndexValue if the policy has selectors or targets deprecated versions
+func indexCRDNames(obj client.Object) []string {
+	policy := obj.(cleanupPolicy)
+	names := []string{}
+	if hasSelectors(policy) {
+		names = append(names, selectorIndexValue)
+	}
+	for _, crdVersion := range policy.GetSpec().CRDsVersions {
+		names = append(names, crdVersion.Name)
+	}
+	for _, entry := range policy.GetStatus().Entries {
+		names = append(names, entry.Name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
 // SetupWithManager sets up the controller with the Manager.
+// ClusterCRDCleanupPolicies are reconciled by the same controller as CRDCleanupPolicies.
 func (r *CRDCleanupPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	for _, obj := range []client.Object{&policiesv1alpha1.CRDCleanupPolicy{}, &policiesv1alpha1.ClusterCRDCleanupPolicy{}} {
+		if err := mgr.GetFieldIndexer().IndexField(context.Background(), obj, crdNameIndexField, indexCRDNames); err != nil {
+			return err
+		}
+	}
+	r.instances = newInstanceWatcher(mgr.GetCache())
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&policiesv1alpha1.CRDCleanupPolicy{}).
-		Complete(r)
+		Watches(&policiesv1alpha1.ClusterCRDCleanupPolicy{}, handler.EnqueueRequestsFromMapFunc(r.findPoliciesForClusterPolicy)).
+		Watches(&policiesv1alpha1.CRDCleanupGrant{}, handler.EnqueueRequestsFromMapFunc(r.findPoliciesForGrant)).
+		Watches(&v1.CustomResourceDefinition{}, handler.EnqueueRequestsFromMapFunc(r.findPoliciesForCRD)).
+		WatchesRawSource(source.Channel(r.instances.events, &handler.EnqueueRequestForObject{}))
+	if r.EmergencyStop != nil && r.EmergencyStop.ConfigMap.Name != "" {
+		// All policies stop and resume as soon as the emergency stop ConfigMap changes
+		b = b.Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.findPoliciesForEmergencyStop),
+			builder.WithPredicates(predicate.NewPredicateFuncs(r.EmergencyStop.isEmergencyStopConfigMap)))
+	}
+	return b.Complete(r)
 }
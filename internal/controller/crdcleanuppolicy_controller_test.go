@@ -18,11 +18,16 @@ package controller
 
 import (
 	"context"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -77,4 +82,314 @@ var _ = Describe("CRDCleanupPolicy Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Context("When reconciling a policy in DryRun mode", func() {
+		const resourceName = "dry-run-policy"
+		const crdName = "dryrunsamples.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating the CRD referenced by the policy")
+			Expect(k8sClient.Create(ctx, newTestCRD("example.com", "dryrunsamples", "DryRunSample", "v1"))).To(Succeed())
+
+			By("creating the CRDCleanupPolicy in DryRun mode")
+			resource := &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					Mode:         policiesv1alpha1.CleanupModeDryRun,
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: crdName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+		})
+
+		It("should plan the deletion without deleting the CRD", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: typeNamespacedName,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			By("checking that the CRD still exists")
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+
+			By("checking that the CRD is recorded in the plan")
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Name).To(Equal(crdName))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhasePlanned))
+			Expect(policy.Status.Entries[0].InstanceCount).To(BeZero())
+
+			By("checking the conditions of the policy")
+			Expect(policy.Status.ObservedGeneration).To(Equal(policy.Generation))
+			Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, policiesv1alpha1.ConditionTypeReady)).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(policy.Status.Conditions, policiesv1alpha1.ConditionTypeDegraded)).To(BeTrue())
+		})
+	})
+
+	Context("When the spec of a policy is edited", func() {
+		const resourceName = "edited-policy"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{
+						{Name: "first.example.com"},
+						{Name: "second.example.com", Version: "v1"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should add, drop and re-evaluate entries", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("changing the version of one entry and replacing the other one")
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			policy.Spec.CRDsVersions = []policiesv1alpha1.CRDCleanupVersion{
+				{Name: "second.example.com", Version: "v2"},
+				{Name: "third.example.com"},
+			}
+			Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.ObservedGeneration).To(Equal(policy.Generation))
+			Expect(policy.Status.Entries).To(HaveLen(2))
+			Expect(policy.Status.Entries[0].Name).To(Equal("second.example.com"))
+			Expect(policy.Status.Entries[0].Version).To(Equal("v2"))
+			Expect(policy.Status.Entries[1].Name).To(Equal("third.example.com"))
+			Expect(policy.Status.Entries[1].Version).To(BeEmpty())
+		})
+	})
+
+	Context("When a CRD has a kind, singular and plural that differ", func() {
+		const resourceName = "cluster-scoped-policy"
+		const crdName = "oddities.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a cluster-scoped CRD and one instance of it")
+			crd := newTestCRD("example.com", "oddities", "StrangeThing", "v1")
+			crd.Spec.Names.Singular = "oddity"
+			crd.Spec.Scope = apiextensionsv1.ClusterScoped
+			Expect(k8sClient.Create(ctx, crd)).To(Succeed())
+
+			instance := &unstructured.Unstructured{}
+			instance.SetAPIVersion("example.com/v1")
+			instance.SetKind("StrangeThing")
+			instance.SetName("strange-thing")
+			Eventually(func() error {
+				return k8sClient.Create(ctx, instance)
+			}).Should(Succeed())
+
+			resource := &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: crdName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+		})
+
+		It("should count the instances through the plural of the CRD", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseBlocked))
+			Expect(policy.Status.Entries[0].InstanceCount).To(BeEquivalentTo(1))
+
+			By("checking that the instance and the CRD are kept")
+			instance := &unstructured.Unstructured{}
+			instance.SetAPIVersion("example.com/v1")
+			instance.SetKind("StrangeThing")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "strange-thing"}, instance)).To(Succeed())
+			Expect(instance.GetDeletionTimestamp()).To(BeNil())
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.DeletionTimestamp).To(BeNil())
+		})
+	})
+
+	Context("When a ClusterCRDCleanupPolicy lists the same CRD as a namespaced policy", func() {
+		const resourceName = "superseded-policy"
+		const clusterResourceName = "cluster-policy"
+		const crdName = "clustersamples.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		clusterName := types.NamespacedName{Name: clusterResourceName}
+
+		BeforeEach(func() {
+			By("creating a CRD and a namespaced and a cluster policy for it")
+			Expect(k8sClient.Create(ctx, newTestCRD("example.com", "clustersamples", "ClusterSample", "v1"))).To(Succeed())
+
+			spec := policiesv1alpha1.CRDCleanupPolicySpec{
+				Mode:         policiesv1alpha1.CleanupModeDryRun,
+				CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: crdName}},
+			}
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       spec,
+			})).To(Succeed())
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.ClusterCRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: clusterResourceName},
+				Spec:       spec,
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			clusterResource := &policiesv1alpha1.ClusterCRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, clusterName, clusterResource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, clusterResource)).To(Succeed())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+		})
+
+		It("should only process the CRD in the cluster policy", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: clusterName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseSuperseded))
+			Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, policiesv1alpha1.ConditionTypeReady)).To(BeTrue())
+
+			clusterPolicy := &policiesv1alpha1.ClusterCRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, clusterName, clusterPolicy)).To(Succeed())
+			Expect(clusterPolicy.Status.Entries).To(HaveLen(1))
+			Expect(clusterPolicy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhasePlanned))
+		})
+	})
 })
+
+// newTestCRD returns a namespaced CRD with the given versions, the first version being the storage version
+func newTestCRD(group, plural, kind string, versions ...string) *apiextensionsv1.CustomResourceDefinition {
+	crdVersions := make([]apiextensionsv1.CustomResourceDefinitionVersion, 0, len(versions))
+	for i, version := range versions {
+		crdVersions = append(crdVersions, apiextensionsv1.CustomResourceDefinitionVersion{
+			Name:    version,
+			Served:  true,
+			Storage: i == 0,
+			Schema: &apiextensionsv1.CustomResourceValidation{
+				OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+					Type:                   "object",
+					XPreserveUnknownFields: ptr.To(true),
+				},
+			},
+		})
+	}
+	return &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: plural + "." + group,
+		},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Group: group,
+			Names: apiextensionsv1.CustomResourceDefinitionNames{
+				Plural:   plural,
+				Singular: strings.ToLower(kind),
+				Kind:     kind,
+			},
+			Scope:    apiextensionsv1.NamespaceScoped,
+			Versions: crdVersions,
+		},
+	}
+}
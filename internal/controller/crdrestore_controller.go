@@ -0,0 +1,539 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/backup"
+)
+
+// CRDRestoreReconciler reconciles CRDRestore objects. It re-creates the CRD of the referenced backup, waits for it
+// to be Established and then re-creates its instances.
+type CRDRestoreReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// APIReader reads directly from the API server. It is used to look up the owners of restored instances.
+	// If it is nil, the Client is used.
+	APIReader client.Reader
+
+	// Backups is the sink the backups are read from. If it is nil, every restore fails.
+	Backups backup.Sink
+
+	// EmergencyStop defers all restores while it is engaged. If it is nil, restores are never deferred.
+	EmergencyStop *EmergencyStop
+}
+
+// +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=crdrestores,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=crdrestores/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=crdrestores/finalizers,verbs=update
+// +kubebuilder:rbac:groups=policies.kreepy.kubecrew.de,resources=crdcleanuppolicies;clustercrdcleanuppolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,verbs=get;list;watch;create;update
+// +kubebuilder:rbac:groups="*",resources="*",verbs=get;list;watch
+
+// The instances of CRDs are only restored with the rights aggregated into the instance editor role,
+// see config/rbac/instance_editor_role.yaml
+
+func (r *CRDRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Starting reconciliation for CRDRestore", "name", req.Name)
+
+	restore := &policiesv1alpha1.CRDRestore{}
+	if err := r.Get(ctx, req.NamespacedName, restore); err != nil {
+		if errors.IsNotFound(err) {
+			log.Info("CRDRestore resource not found. Ignoring since object must be deleted")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "Failed to get CRDRestore")
+		return ctrl.Result{}, err
+	}
+	if restore.Status.ObservedGeneration != restore.Generation {
+		// The backup changed, so the results of the previous backup do not apply anymore
+		restore.Status = policiesv1alpha1.CRDRestoreStatus{Conditions: restore.Status.Conditions}
+	} else if restore.Status.Phase == policiesv1alpha1.CRDRestorePhaseCompleted {
+		return ctrl.Result{}, nil
+	}
+
+	// Restores create and update objects, so they wait until the emergency stop is released
+	if reason := r.EmergencyStop.Reason(ctx, r.Client); reason != "" {
+		log.Info("Deferring restore while the emergency stop is engaged", "Reason", reason)
+		restore.Status.Phase = policiesv1alpha1.CRDRestorePhasePending
+		restore.Status.Message = reason
+		if err := r.updateRestoreStatus(ctx, restore, log); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: retryPeriod}, nil
+	}
+
+	result, err := r.restore(ctx, restore, log)
+	if err != nil {
+		restore.Status.Phase = policiesv1alpha1.CRDRestorePhaseFailed
+		restore.Status.Message = err.Error()
+		result = ctrl.Result{RequeueAfter: retryPeriod}
+	}
+	if err := r.updateRestoreStatus(ctx, restore, log); err != nil {
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// restore restores the CRD and, once it is Established, the instances of the backup. Objects that could not be
+// restored are recorded in the status and make the restore fail, but do not abort it.
+func (r *CRDRestoreReconciler) restore(ctx context.Context, restore *policiesv1alpha1.CRDRestore, log logr.Logger) (ctrl.Result, error) {
+	snapshot, err := r.readBackup(ctx, restore, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	restore.Status.CRD = snapshot.CRD.GetName()
+	// Once the CRD is restored, the entry may be removed from the policy, so the backup is only verified before
+	if phase := restoredObjectPhase(restore, snapshot.CRD); phase == "" || phase == policiesv1alpha1.RestoredObjectPhaseFailed {
+		if err := r.verifyBackup(ctx, restore.Spec.Backup, snapshot, log); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	crd, err := r.restoreCRD(ctx, restore, snapshot.CRD, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !isEstablished(crd) {
+		log.Info("Waiting for the restored CRD to be Established", "CRD", crd.Name)
+		restore.Status.Phase = policiesv1alpha1.CRDRestorePhaseEstablishing
+		restore.Status.Message = fmt.Sprintf("Waiting for CRD %s to be Established", crd.Name)
+		return ctrl.Result{RequeueAfter: retryPeriod}, nil
+	}
+
+	restore.Status.Phase = policiesv1alpha1.CRDRestorePhaseRestoring
+	failed := 0
+	forbidden := false
+	for _, instance := range sortInstances(snapshot.Instances) {
+		if phase := restoredObjectPhase(restore, &instance); phase != "" && phase != policiesv1alpha1.RestoredObjectPhaseFailed {
+			continue
+		}
+		phase, err := r.restoreInstance(ctx, crd, instance.DeepCopy(), log)
+		if err != nil {
+			failed++
+			forbidden = forbidden || errors.IsForbidden(err)
+		}
+		recordRestoredObject(restore, &instance, phase, err)
+	}
+
+	if failed > 0 {
+		restore.Status.Phase = policiesv1alpha1.CRDRestorePhaseFailed
+		restore.Status.Message = fmt.Sprintf("%d of %d instances could not be restored", failed, len(snapshot.Instances))
+		if forbidden {
+			restore.Status.Message = fmt.Sprintf("%s. %s", restore.Status.Message, instanceAccessHint(crd))
+		}
+		return ctrl.Result{RequeueAfter: retryPeriod}, nil
+	}
+	log.Info("Restored CRD and its instances", "CRD", crd.Name, "Instances", len(snapshot.Instances))
+	restore.Status.Phase = policiesv1alpha1.CRDRestorePhaseCompleted
+	restore.Status.Message = fmt.Sprintf("Restored CRD %s and %d instances", crd.Name, len(snapshot.Instances))
+	restore.Status.CompletionTime = ptr.To(metav1.Now())
+	return ctrl.Result{}, nil
+}
+
+// readBackup reads and decodes the referenced backup
+func (r *CRDRestoreReconciler) readBackup(ctx context.Context, restore *policiesv1alpha1.CRDRestore, log logr.Logger) (*backup.Backup, error) {
+	if r.Backups == nil {
+		return nil, fmt.Errorf("no backup sink is configured")
+	}
+	data, err := r.Backups.Read(ctx, restore.Spec.Backup)
+	if err != nil {
+		log.Error(err, "Failed to read the backup", "Sink", restore.Spec.Backup.Sink, "Location", restore.Spec.Backup.Location)
+		return nil, err
+	}
+	snapshot, err := backup.Decode(data)
+	if err != nil {
+		log.Error(err, "Failed to decode the backup", "Sink", restore.Spec.Backup.Sink, "Location", restore.Spec.Backup.Location)
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// verifyBackup checks that the backup was recorded by a policy and contains the CRD the policy backed up, so that a
+// CRDRestore cannot make the operator create a CRD from a backup it did not write itself
+func (r *CRDRestoreReconciler) verifyBackup(ctx context.Context, ref policiesv1alpha1.BackupReference, snapshot *backup.Backup, log logr.Logger) error {
+	if gvk := snapshot.CRD.GroupVersionKind(); gvk.GroupKind() != v1.SchemeGroupVersion.WithKind("CustomResourceDefinition").GroupKind() {
+		return fmt.Errorf("backup contains a %s instead of a CRD", gvk.Kind)
+	}
+
+	policies := &policiesv1alpha1.CRDCleanupPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		log.Error(err, "Failed to list CRDCleanupPolicies")
+		return err
+	}
+	clusterPolicies := &policiesv1alpha1.ClusterCRDCleanupPolicyList{}
+	if err := r.List(ctx, clusterPolicies); err != nil {
+		log.Error(err, "Failed to list ClusterCRDCleanupPolicies")
+		return err
+	}
+	var entries []policiesv1alpha1.CRDCleanupEntryStatus
+	for _, policy := range policies.Items {
+		entries = append(entries, policy.Status.Entries...)
+	}
+	for _, policy := range clusterPolicies.Items {
+		entries = append(entries, policy.Status.Entries...)
+	}
+
+	for _, entry := range entries {
+		if entry.Backup == nil || entry.Backup.Sink != ref.Sink || entry.Backup.Location != ref.Location || entry.Backup.Namespace != ref.Namespace {
+			continue
+		}
+		if entry.Name != snapshot.CRD.GetName() {
+			return fmt.Errorf("backup of CRD %s contains CRD %s", entry.Name, snapshot.CRD.GetName())
+		}
+		return nil
+	}
+	return fmt.Errorf("backup %s is not recorded by any CRDCleanupPolicy or ClusterCRDCleanupPolicy", ref.Location)
+}
+
+// restoreCRD creates the CRD of the backup. If the CRD exists, e.g. because only a version of it was removed,
+// the versions of the backup it is missing are added to it without changing its storage version.
+func (r *CRDRestoreReconciler) restoreCRD(ctx context.Context, restore *policiesv1alpha1.CRDRestore, obj *unstructured.Unstructured, log logr.Logger) (*v1.CustomResourceDefinition, error) {
+	backupCRD := &v1.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, backupCRD); err != nil {
+		log.Error(err, "Failed to convert the CRD of the backup", "CRD", obj.GetName())
+		recordRestoredObject(restore, obj, policiesv1alpha1.RestoredObjectPhaseFailed, err)
+		return nil, err
+	}
+
+	crd := &v1.CustomResourceDefinition{}
+	err := r.Get(ctx, client.ObjectKey{Name: backupCRD.Name}, crd)
+	switch {
+	case errors.IsNotFound(err):
+		if err := r.Create(ctx, backupCRD); err != nil {
+			log.Error(err, "Failed to create CRD", "CRD", backupCRD.Name)
+			recordRestoredObject(restore, obj, policiesv1alpha1.RestoredObjectPhaseFailed, err)
+			return nil, err
+		}
+		log.Info("Created CRD from backup", "CRD", backupCRD.Name)
+		recordRestoredObject(restore, obj, policiesv1alpha1.RestoredObjectPhaseCreated, nil)
+		return backupCRD, nil
+	case err != nil:
+		log.Error(err, "Failed to get CRD", "CRD", backupCRD.Name)
+		return nil, err
+	}
+
+	missing := []string{}
+	for _, version := range backupCRD.Spec.Versions {
+		if !slices.ContainsFunc(crd.Spec.Versions, func(v v1.CustomResourceDefinitionVersion) bool { return v.Name == version.Name }) {
+			version.Storage = false
+			crd.Spec.Versions = append(crd.Spec.Versions, version)
+			missing = append(missing, version.Name)
+		}
+	}
+	if len(missing) == 0 {
+		recordRestoredObject(restore, obj, policiesv1alpha1.RestoredObjectPhaseExists, nil)
+		return crd, nil
+	}
+	if err := r.Update(ctx, crd); err != nil {
+		log.Error(err, "Failed to add the versions of the backup to CRD", "CRD", crd.Name, "Versions", missing)
+		recordRestoredObject(restore, obj, policiesv1alpha1.RestoredObjectPhaseFailed, err)
+		return nil, err
+	}
+	log.Info("Added the versions of the backup to CRD", "CRD", crd.Name, "Versions", missing)
+	recordRestoredObject(restore, obj, policiesv1alpha1.RestoredObjectPhaseUpdated, nil)
+	return crd, nil
+}
+
+// restoreInstance creates an instance of the backup, including its status if the CRD has a status subresource.
+// Instances that exist are left unchanged.
+func (r *CRDRestoreReconciler) restoreInstance(ctx context.Context, crd *v1.CustomResourceDefinition, instance *unstructured.Unstructured, log logr.Logger) (policiesv1alpha1.RestoredObjectPhase, error) {
+	if !servesInstance(crd, instance) {
+		err := fmt.Errorf("%s %s is not an instance of CRD %s", instance.GetAPIVersion(), instance.GetKind(), crd.Name)
+		log.Error(err, "Refusing to restore instance", "Namespace", instance.GetNamespace(), "Name", instance.GetName())
+		return policiesv1alpha1.RestoredObjectPhaseFailed, err
+	}
+	status, hasStatus := instance.Object["status"]
+	if err := r.resolveOwnerReferences(ctx, instance, log); err != nil {
+		return policiesv1alpha1.RestoredObjectPhaseFailed, err
+	}
+	if err := r.Create(ctx, instance); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return policiesv1alpha1.RestoredObjectPhaseExists, nil
+		}
+		log.Error(err, "Failed to restore instance", "CRD", crd.Name, "Namespace", instance.GetNamespace(), "Name", instance.GetName())
+		return policiesv1alpha1.RestoredObjectPhaseFailed, instanceAccessError(err, crd)
+	}
+	if !hasStatus || !hasStatusSubresource(crd, instance.GroupVersionKind().Version) {
+		return policiesv1alpha1.RestoredObjectPhaseCreated, nil
+	}
+
+	instance.Object["status"] = status
+	if err := r.Status().Update(ctx, instance); err != nil {
+		log.Error(err, "Failed to restore the status of instance", "CRD", crd.Name, "Namespace", instance.GetNamespace(), "Name", instance.GetName())
+		return policiesv1alpha1.RestoredObjectPhaseFailed, fmt.Errorf("created, but failed to restore the status: %w", err)
+	}
+	return policiesv1alpha1.RestoredObjectPhaseCreated, nil
+}
+
+// resolveOwnerReferences points the owner references of the instance to the current UIDs of its owners, which
+// changed if the owners were restored as well. References to owners that do not exist anymore are dropped, since
+// the garbage collector would delete the instance otherwise.
+func (r *CRDRestoreReconciler) resolveOwnerReferences(ctx context.Context, instance *unstructured.Unstructured, log logr.Logger) error {
+	refs := []metav1.OwnerReference{}
+	for _, ref := range instance.GetOwnerReferences() {
+		gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+		key := client.ObjectKey{Namespace: instance.GetNamespace(), Name: ref.Name}
+		mapping, err := r.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err == nil && mapping.Scope.Name() == meta.RESTScopeNameRoot {
+			key.Namespace = ""
+		}
+		owner := &metav1.PartialObjectMetadata{}
+		owner.SetGroupVersionKind(gvk)
+		if err == nil {
+			err = r.apiReader().Get(ctx, key, owner)
+		}
+		switch {
+		case err == nil:
+			ref.UID = owner.GetUID()
+			refs = append(refs, ref)
+		case errors.IsNotFound(err) || meta.IsNoMatchError(err):
+			log.Info("Dropping the owner reference to a missing owner", "Namespace", instance.GetNamespace(), "Name", instance.GetName(),
+				"Owner", ref.Name, "OwnerKind", ref.Kind)
+		default:
+			log.Error(err, "Failed to get the owner of instance", "Namespace", instance.GetNamespace(), "Name", instance.GetName(),
+				"Owner", ref.Name, "OwnerKind", ref.Kind)
+			return err
+		}
+	}
+	instance.SetOwnerReferences(refs)
+	return nil
+}
+
+// apiReader returns the reader that bypasses the cache, or the Client if none is configured
+func (r *CRDRestoreReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+// sortInstances returns the instances in a dependency-safe order: instances owned by other instances of the backup
+// are restored after their owners, so their owner references can be resolved. Apart from that, the instances are
+// ordered by namespace and name. Instances with cyclic owner references are restored last.
+func sortInstances(instances []unstructured.Unstructured) []unstructured.Unstructured {
+	pending := slices.Clone(instances)
+	slices.SortStableFunc(pending, func(a, b unstructured.Unstructured) int {
+		return cmp.Or(cmp.Compare(a.GetNamespace(), b.GetNamespace()), cmp.Compare(a.GetName(), b.GetName()))
+	})
+	inBackup := map[types.NamespacedName]bool{}
+	for _, instance := range pending {
+		inBackup[types.NamespacedName{Namespace: instance.GetNamespace(), Name: instance.GetName()}] = true
+	}
+
+	sorted := make([]unstructured.Unstructured, 0, len(pending))
+	restored := map[types.NamespacedName]bool{}
+	for len(pending) > 0 {
+		remaining := []unstructured.Unstructured{}
+		for _, instance := range pending {
+			if ownersRestored(&instance, inBackup, restored) {
+				sorted = append(sorted, instance)
+				restored[types.NamespacedName{Namespace: instance.GetNamespace(), Name: instance.GetName()}] = true
+			} else {
+				remaining = append(remaining, instance)
+			}
+		}
+		if len(remaining) == len(pending) {
+			return append(sorted, remaining...)
+		}
+		pending = remaining
+	}
+	return sorted
+}
+
+// ownersRestored returns whether all owners of the instance that are part of the backup have been restored
+func ownersRestored(instance *unstructured.Unstructured, inBackup, restored map[types.NamespacedName]bool) bool {
+	for _, ref := range instance.GetOwnerReferences() {
+		gvk := schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind)
+		if gvk.GroupKind() != instance.GroupVersionKind().GroupKind() {
+			continue
+		}
+		owner := types.NamespacedName{Namespace: instance.GetNamespace(), Name: ref.Name}
+		if inBackup[owner] && !restored[owner] {
+			return false
+		}
+	}
+	return true
+}
+
+// isEstablished returns whether the API server serves the CRD
+func isEstablished(crd *v1.CustomResourceDefinition) bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == v1.Established {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// hasStatusSubresource returns whether the given version of the CRD has a status subresource
+func hasStatusSubresource(crd *v1.CustomResourceDefinition, crdVersion string) bool {
+	for _, version := range crd.Spec.Versions {
+		if version.Name == crdVersion {
+			return version.Subresources != nil && version.Subresources.Status != nil
+		}
+	}
+	return false
+}
+
+// servesInstance returns whether the object is of the kind of the CRD and of one of its served versions
+func servesInstance(crd *v1.CustomResourceDefinition, obj *unstructured.Unstructured) bool {
+	gvk := obj.GroupVersionKind()
+	if gvk.Group != crd.Spec.Group || gvk.Kind != crd.Spec.Names.Kind {
+		return false
+	}
+	return slices.ContainsFunc(crd.Spec.Versions, func(v v1.CustomResourceDefinitionVersion) bool {
+		return v.Name == gvk.Version && v.Served
+	})
+}
+
+// restoredObjectPhase returns the phase recorded for the object, or an empty phase if it was not restored yet
+func restoredObjectPhase(restore *policiesv1alpha1.CRDRestore, obj *unstructured.Unstructured) policiesv1alpha1.RestoredObjectPhase {
+	for _, status := range restore.Status.Objects {
+		if isRestoredObject(&status, obj) {
+			return status.Phase
+		}
+	}
+	return ""
+}
+
+// recordRestoredObject records the result of restoring the object. Objects found to exist keep the result of
+// the attempt that created or updated them.
+func recordRestoredObject(restore *policiesv1alpha1.CRDRestore, obj *unstructured.Unstructured, phase policiesv1alpha1.RestoredObjectPhase, err error) {
+	status := policiesv1alpha1.RestoredObjectStatus{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Phase:      phase,
+	}
+	if err != nil {
+		status.Message = err.Error()
+	}
+	for i := range restore.Status.Objects {
+		existing := &restore.Status.Objects[i]
+		if !isRestoredObject(existing, obj) {
+			continue
+		}
+		if phase == policiesv1alpha1.RestoredObjectPhaseExists && existing.Phase != policiesv1alpha1.RestoredObjectPhaseFailed {
+			return
+		}
+		*existing = status
+		return
+	}
+	restore.Status.Objects = append(restore.Status.Objects, status)
+}
+
+// isRestoredObject returns whether the status refers to the object
+func isRestoredObject(status *policiesv1alpha1.RestoredObjectStatus, obj *unstructured.Unstructured) bool {
+	return status.APIVersion == obj.GetAPIVersion() && status.Kind == obj.GetKind() &&
+		status.Namespace == obj.GetNamespace() && status.Name == obj.GetName()
+}
+
+// updateRestoreStatus updates the status of the CRDRestore
+func (r *CRDRestoreReconciler) updateRestoreStatus(ctx context.Context, restore *policiesv1alpha1.CRDRestore, log logr.Logger) error {
+	restored := 0
+	for _, status := range restore.Status.Objects {
+		if status.Phase != policiesv1alpha1.RestoredObjectPhaseFailed {
+			restored++
+		}
+	}
+	restore.Status.Progress = fmt.Sprintf("%d/%d", restored, len(restore.Status.Objects))
+	restore.Status.ObservedGeneration = restore.Generation
+	setRestoreConditions(restore)
+
+	if err := r.Status().Update(ctx, restore); err != nil {
+		log.Error(err, "Failed to update CRDRestore status", "restore", restore.Name)
+		return err
+	}
+	return nil
+}
+
+// setRestoreConditions derives the Ready and Degraded conditions from the phase of the restore
+func setRestoreConditions(restore *policiesv1alpha1.CRDRestore) {
+	ready := metav1.Condition{Type: policiesv1alpha1.ConditionTypeReady, Status: metav1.ConditionFalse}
+	degraded := metav1.Condition{Type: policiesv1alpha1.ConditionTypeDegraded, Status: metav1.ConditionFalse,
+		Reason: "NoFailures", Message: "No objects failed to restore"}
+	switch restore.Status.Phase {
+	case policiesv1alpha1.CRDRestorePhaseCompleted:
+		ready.Status = metav1.ConditionTrue
+		ready.Reason = "Restored"
+	case policiesv1alpha1.CRDRestorePhasePending:
+		ready.Reason = "Pending"
+	case policiesv1alpha1.CRDRestorePhaseFailed:
+		ready.Reason = "RestoreFailed"
+		degraded.Status = metav1.ConditionTrue
+		degraded.Reason = "RestoreFailed"
+		degraded.Message = restore.Status.Message
+	default:
+		ready.Reason = "Restoring"
+	}
+	ready.Message = restore.Status.Message
+
+	for _, condition := range []metav1.Condition{ready, degraded} {
+		condition.ObservedGeneration = restore.Generation
+		meta.SetStatusCondition(&restore.Status.Conditions, condition)
+	}
+}
+
+// findRestoresForCRD returns a reconcile request for every restore waiting for the given CRD to be Established
+func (r *CRDRestoreReconciler) findRestoresForCRD(ctx context.Context, crd client.Object) []reconcile.Request {
+	restores := &policiesv1alpha1.CRDRestoreList{}
+	if err := r.List(ctx, restores); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list CRDRestores for CRD", "CRD", crd.GetName())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, restore := range restores.Items {
+		if restore.Status.CRD == crd.GetName() && restore.Status.Phase == policiesv1alpha1.CRDRestorePhaseEstablishing {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&restore)})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CRDRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&policiesv1alpha1.CRDRestore{}).
+		Watches(&v1.CustomResourceDefinition{}, handler.EnqueueRequestsFromMapFunc(r.findRestoresForCRD)).
+		Named("crdrestore").
+		Complete(r)
+}
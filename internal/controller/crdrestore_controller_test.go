@@ -0,0 +1,248 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/backup"
+)
+
+var _ = Describe("CRDRestore Controller", func() {
+	Context("When restoring a backup", func() {
+		const resourceName = "restore-keepsakes"
+		const crdName = "heirlooms.restore.example.com"
+		const recorderName = "restore-recorder"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{Name: resourceName}
+		var sink *backup.DirectorySink
+
+		BeforeEach(func() {
+			By("writing a backup of a CRD with an owner and an owned instance")
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(newTestCRD("restore.example.com", "heirlooms", "Heirloom", "v1"))
+			Expect(err).NotTo(HaveOccurred())
+			crd := &unstructured.Unstructured{Object: content}
+			crd.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+
+			owner := newTestInstance("parent")
+			child := newTestInstance("child")
+			child.SetOwnerReferences([]metav1.OwnerReference{{
+				APIVersion: "restore.example.com/v1",
+				Kind:       "Heirloom",
+				Name:       "parent",
+				UID:        "stale",
+			}})
+			data, err := backup.Encode(&backup.Backup{CRD: crd, Instances: []unstructured.Unstructured{*child, *owner}})
+			Expect(err).NotTo(HaveOccurred())
+
+			sink = &backup.DirectorySink{Path: GinkgoT().TempDir()}
+			ref, err := sink.Write(ctx, "heirlooms", data)
+			Expect(err).NotTo(HaveOccurred())
+
+			By("recording the backup in the status of a policy")
+			policy := &policiesv1alpha1.ClusterCRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: recorderName},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: crdName}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+			policy.Status.Entries = []policiesv1alpha1.CRDCleanupEntryStatus{{
+				Name:   crdName,
+				Phase:  policiesv1alpha1.CRDCleanupPhaseDeleted,
+				Backup: &policiesv1alpha1.CRDBackupStatus{BackupReference: ref, Instances: 2, Time: metav1.Now()},
+			}}
+			Expect(k8sClient.Status().Update(ctx, policy)).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec:       policiesv1alpha1.CRDRestoreSpec{Backup: ref},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDRestore{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			policy := &policiesv1alpha1.ClusterCRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: recorderName}, policy)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, policy)).To(Succeed())
+		})
+
+		It("should re-create the CRD and its instances with resolved owner references", func() {
+			controllerReconciler := &CRDRestoreReconciler{
+				Client:  k8sClient,
+				Scheme:  k8sClient.Scheme(),
+				Backups: sink,
+			}
+
+			restore := &policiesv1alpha1.CRDRestore{}
+			Eventually(func() policiesv1alpha1.CRDRestorePhase {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, typeNamespacedName, restore)).To(Succeed())
+				return restore.Status.Phase
+			}).Should(Equal(policiesv1alpha1.CRDRestorePhaseCompleted))
+
+			Expect(restore.Status.CRD).To(Equal(crdName))
+			Expect(restore.Status.Progress).To(Equal("3/3"))
+			Expect(restore.Status.Objects[0].Phase).To(Equal(policiesv1alpha1.RestoredObjectPhaseCreated))
+			Expect(restore.Status.Objects[1].Name).To(Equal("parent"))
+			Expect(restore.Status.Objects[2].Name).To(Equal("child"))
+
+			By("checking that the child references the restored parent")
+			parent := newTestInstance("parent")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "parent"}, parent)).To(Succeed())
+			child := newTestInstance("child")
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "child"}, child)).To(Succeed())
+			Expect(child.GetOwnerReferences()).To(HaveLen(1))
+			Expect(child.GetOwnerReferences()[0].UID).To(Equal(parent.GetUID()))
+		})
+	})
+
+	// Backups are written by the operator, but their references are chosen by whoever creates the CRDRestore, so
+	// these tests forge references and run against a fake client
+	Context("When restoring a backup the operator did not record", func() {
+		const crdName = "heirlooms.restore.example.com"
+
+		var sink *backup.DirectorySink
+		var restoreKey types.NamespacedName
+
+		// newReconciler returns a reconciler whose client holds the given objects and a CRDRestore of the backup
+		// with the given CRD and instances
+		newReconciler := func(crd *apiextensionsv1.CustomResourceDefinition, instances []unstructured.Unstructured, objs ...client.Object) *CRDRestoreReconciler {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+			Expect(err).NotTo(HaveOccurred())
+			backupCRD := &unstructured.Unstructured{Object: content}
+			backupCRD.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+			data, err := backup.Encode(&backup.Backup{CRD: backupCRD, Instances: instances})
+			Expect(err).NotTo(HaveOccurred())
+			ref, err := sink.Write(ctx, crd.Name, data)
+			Expect(err).NotTo(HaveOccurred())
+
+			restore := &policiesv1alpha1.CRDRestore{
+				ObjectMeta: metav1.ObjectMeta{Name: "forged-restore"},
+				Spec:       policiesv1alpha1.CRDRestoreSpec{Backup: ref},
+			}
+			restoreKey = client.ObjectKeyFromObject(restore)
+			c := fake.NewClientBuilder().
+				WithScheme(scheme.Scheme).
+				WithObjects(append(objs, restore)...).
+				WithStatusSubresource(&policiesv1alpha1.CRDRestore{}).
+				Build()
+			return &CRDRestoreReconciler{Client: c, Scheme: scheme.Scheme, Backups: sink}
+		}
+
+		// recordingPolicy returns a policy that recorded the backup of the CRD with the given name
+		recordingPolicy := func(name string) *policiesv1alpha1.ClusterCRDCleanupPolicy {
+			policy := &policiesv1alpha1.ClusterCRDCleanupPolicy{ObjectMeta: metav1.ObjectMeta{Name: "recording-policy"}}
+			policy.Status.Entries = []policiesv1alpha1.CRDCleanupEntryStatus{{
+				Name:  name,
+				Phase: policiesv1alpha1.CRDCleanupPhaseDeleted,
+				Backup: &policiesv1alpha1.CRDBackupStatus{
+					BackupReference: policiesv1alpha1.BackupReference{
+						Sink:     policiesv1alpha1.BackupSinkDirectory,
+						Location: filepath.Join(sink.Path, crdName+".json"),
+					},
+				},
+			}}
+			return policy
+		}
+
+		// reconcileRestore reconciles the CRDRestore once and returns it
+		reconcileRestore := func(r *CRDRestoreReconciler) *policiesv1alpha1.CRDRestore {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: restoreKey})
+			Expect(err).NotTo(HaveOccurred())
+			restore := &policiesv1alpha1.CRDRestore{}
+			Expect(r.Get(ctx, restoreKey, restore)).To(Succeed())
+			return restore
+		}
+
+		BeforeEach(func() {
+			sink = &backup.DirectorySink{Path: GinkgoT().TempDir()}
+		})
+
+		It("should not create the CRD of a backup no policy recorded", func() {
+			r := newReconciler(newTestCRD("restore.example.com", "heirlooms", "Heirloom", "v1"), nil)
+
+			restore := reconcileRestore(r)
+			Expect(restore.Status.Phase).To(Equal(policiesv1alpha1.CRDRestorePhaseFailed))
+			Expect(restore.Status.Message).To(ContainSubstring("is not recorded by any CRDCleanupPolicy"))
+			Expect(errors.IsNotFound(r.Get(ctx, types.NamespacedName{Name: crdName}, &apiextensionsv1.CustomResourceDefinition{}))).To(BeTrue())
+		})
+
+		It("should not create a CRD other than the one the policy backed up", func() {
+			r := newReconciler(newTestCRD("restore.example.com", "heirlooms", "Heirloom", "v1"), nil,
+				recordingPolicy("keepsakes.restore.example.com"))
+
+			restore := reconcileRestore(r)
+			Expect(restore.Status.Phase).To(Equal(policiesv1alpha1.CRDRestorePhaseFailed))
+			Expect(restore.Status.Message).To(Equal("backup of CRD keepsakes.restore.example.com contains CRD " + crdName))
+			Expect(errors.IsNotFound(r.Get(ctx, types.NamespacedName{Name: crdName}, &apiextensionsv1.CustomResourceDefinition{}))).To(BeTrue())
+		})
+
+		It("should only restore instances of the CRD", func() {
+			crd := newTestCRD("restore.example.com", "heirlooms", "Heirloom", "v1")
+			established := crd.DeepCopy()
+			established.Status.Conditions = []apiextensionsv1.CustomResourceDefinitionCondition{{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue}}
+			secret := &unstructured.Unstructured{}
+			secret.SetAPIVersion("v1")
+			secret.SetKind("Secret")
+			secret.SetNamespace("default")
+			secret.SetName("smuggled")
+			r := newReconciler(crd, []unstructured.Unstructured{*secret}, recordingPolicy(crdName), established)
+
+			restore := reconcileRestore(r)
+			Expect(restore.Status.Phase).To(Equal(policiesv1alpha1.CRDRestorePhaseFailed))
+			Expect(restore.Status.Objects).To(ContainElement(And(
+				HaveField("Name", "smuggled"),
+				HaveField("Phase", policiesv1alpha1.RestoredObjectPhaseFailed),
+				HaveField("Message", ContainSubstring("is not an instance of CRD "+crdName)),
+			)))
+			Expect(errors.IsNotFound(r.Get(ctx, types.NamespacedName{Namespace: "default", Name: "smuggled"}, &corev1.Secret{}))).To(BeTrue())
+		})
+	})
+})
+
+// newTestInstance returns an instance of the Heirloom test CRD in the default namespace
+func newTestInstance(name string) *unstructured.Unstructured {
+	instance := &unstructured.Unstructured{}
+	instance.SetAPIVersion("restore.example.com/v1")
+	instance.SetKind("Heirloom")
+	instance.SetNamespace("default")
+	instance.SetName(name)
+	return instance
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// namespaceGrants returns the CRDCleanupGrants that apply to the given namespace
+func (r *CRDCleanupPolicyReconciler) namespaceGrants(ctx context.Context, namespace string, log logr.Logger) ([]policiesv1alpha1.CRDCleanupGrant, error) {
+	grants := &policiesv1alpha1.CRDCleanupGrantList{}
+	if err := r.List(ctx, grants); err != nil {
+		log.Error(err, "Failed to list CRDCleanupGrants")
+		return nil, err
+	}
+
+	var namespaceLabels labels.Set
+	applicable := []policiesv1alpha1.CRDCleanupGrant{}
+	for _, grant := range grants.Items {
+		if slices.Contains(grant.Spec.Namespaces, namespace) {
+			applicable = append(applicable, grant)
+			continue
+		}
+		if grant.Spec.NamespaceSelector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(grant.Spec.NamespaceSelector)
+		if err != nil {
+			log.Error(err, "Ignoring CRDCleanupGrant with an invalid namespace selector", "CRDCleanupGrant", grant.Name)
+			continue
+		}
+		// The labels of the namespace are only fetched if a grant selects namespaces by their labels
+		if namespaceLabels == nil {
+			ns := &corev1.Namespace{}
+			if err := r.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+				log.Error(err, "Failed to fetch namespace of the policy", "Namespace", namespace)
+				return nil, err
+			}
+			namespaceLabels = labels.Set(ns.Labels)
+		}
+		if selector.Matches(namespaceLabels) {
+			applicable = append(applicable, grant)
+		}
+	}
+	return applicable, nil
+}
+
+// grantFor returns the name of the first grant that allows targeting the given CRD, or an empty string if none does
+func grantFor(grants []policiesv1alpha1.CRDCleanupGrant, crdName string) string {
+	// The name of a CRD is <plural>.<group>
+	_, group, _ := strings.Cut(crdName, ".")
+	for _, grant := range grants {
+		if matchesAny(grant.Spec.Names, crdName) || matchesAny(grant.Spec.Groups, group) {
+			return grant.Name
+		}
+	}
+	return ""
+}
+
+// matchesAny returns true if the value matches any of the given glob patterns
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(pattern, value); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// findPoliciesForGrant returns a reconcile request for every namespaced policy, since any of them
+// may be granted or denied access to its CRDs by the changed grant
+func (r *CRDCleanupPolicyReconciler) findPoliciesForGrant(ctx context.Context, grant client.Object) []reconcile.Request {
+	policies := &policiesv1alpha1.CRDCleanupPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list CRDCleanupPolicies for CRDCleanupGrant", "CRDCleanupGrant", grant.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(policies.Items))
+	for _, policy := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policy)})
+	}
+	return requests
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("CRDCleanupGrants", func() {
+	Context("When CRDCleanupGrants are enforced", func() {
+		const resourceName = "granted-policy"
+		const grantName = "example-grant"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("granting the default namespace access to a single API group")
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: grantName},
+				Spec: policiesv1alpha1.CRDCleanupGrantSpec{
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"kubernetes.io/metadata.name": "default"},
+					},
+					Groups: []string{"*.granted.example.com"},
+				},
+			})).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{
+						{Name: "things.team.granted.example.com"},
+						{Name: "things.other.example.com"},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			grant := &policiesv1alpha1.CRDCleanupGrant{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: grantName}, grant)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, grant)).To(Succeed())
+		})
+
+		It("should deny entries that are not granted to the namespace", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				EnforceGrants: true,
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(2))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseNotFound))
+			Expect(policy.Status.Entries[1].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDenied))
+			Expect(meta.IsStatusConditionTrue(policy.Status.Conditions, policiesv1alpha1.ConditionTypeBlocked)).To(BeTrue())
+		})
+	})
+})
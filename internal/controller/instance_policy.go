@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// instancePolicyFor returns the instance policy of the entry, which defaults to the instance policy of the policy
+func instancePolicyFor(policy cleanupPolicy, entry *policiesv1alpha1.CRDCleanupEntryStatus) policiesv1alpha1.InstancePolicy {
+	if crdVersion := specEntry(policy, entry); crdVersion != nil && crdVersion.InstancePolicy != "" {
+		return crdVersion.InstancePolicy
+	}
+	if instancePolicy := policy.GetSpec().InstancePolicy; instancePolicy != "" {
+		return instancePolicy
+	}
+	return policiesv1alpha1.InstancePolicyBlock
+}
+
+// drainInstances deletes all instances of the CRD that are not terminating yet and returns the number of deleted
+// instances. The CRD is deleted once the instances are gone.
+func (r *CRDCleanupPolicyReconciler) drainInstances(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger) (int, error) {
+	mapping, err := r.instanceMapping(crd, "")
+	if err != nil {
+		log.Error(err, "Failed to resolve instances of CRD", "CRD", crd.GetName())
+		return 0, err
+	}
+
+	deleted := 0
+	instances := &metav1.PartialObjectMetadataList{}
+	instances.SetGroupVersionKind(mapping.GroupVersionKind.GroupVersion().WithKind(mapping.GroupVersionKind.Kind + "List"))
+	for {
+		if err := r.apiReader().List(ctx, instances, client.Limit(instanceListPageSize), client.Continue(instances.GetContinue())); err != nil {
+			if errors.IsNotFound(err) {
+				return deleted, nil
+			}
+			log.Error(err, "Failed to list instances of CRD", "CRD", crd.GetName())
+			return deleted, err
+		}
+		for i := range instances.Items {
+			instance := &instances.Items[i]
+			if instance.DeletionTimestamp != nil {
+				continue
+			}
+			instance.SetGroupVersionKind(mapping.GroupVersionKind)
+			if err := r.Delete(ctx, instance, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				log.Error(err, "Failed to delete instance of CRD", "CRD", crd.GetName(), "Namespace", instance.Namespace, "Name", instance.Name)
+				return deleted, instanceAccessError(err, crd)
+			}
+			deleted++
+		}
+		if instances.GetContinue() == "" {
+			log.Info("Deleted instances of CRD", "CRD", crd.GetName(), "Count", deleted)
+			return deleted, nil
+		}
+	}
+}
+
+// handleInstances applies the instance policy of the entry to the existing instances of the CRD. The entry is
+// blocked or waits for the instances to be gone, in both cases the instances are watched.
+func (r *CRDCleanupPolicyReconciler) handleInstances(ctx context.Context, policy cleanupPolicy, crd *v1.CustomResourceDefinition, entry *policiesv1alpha1.CRDCleanupEntryStatus, instanceCount int, log logr.Logger) {
+	switch instancePolicy := instancePolicyFor(policy, entry); instancePolicy {
+	case policiesv1alpha1.InstancePolicyDelete, policiesv1alpha1.InstancePolicyBackupThenDelete:
+		if instancePolicy == policiesv1alpha1.InstancePolicyBackupThenDelete && r.Backups == nil {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseBlocked, "No backup sink is configured to back up the instances of the CRD", nil)
+			return
+		}
+		if isDryRun(policy) {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhasePlanned, fmt.Sprintf("The %d instances and the CRD would be deleted", instanceCount), nil)
+			return
+		}
+		if hold := r.holdFor(ctx, policy); hold != nil {
+			hold.apply(entry, fmt.Sprintf("the %d instances and the CRD are deleted afterwards", instanceCount))
+			return
+		}
+		// The instances are backed up before they are deleted whenever a backup sink is configured
+		if err := r.backupEntry(ctx, policy, crd, entry, log); err != nil {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, "Failed to back up the CRD", err)
+			return
+		}
+		deleted, err := r.drainInstances(ctx, crd, log)
+		entry.DeletedInstances += int32(deleted)
+		if blockOnInstanceAccess(entry, crd, err) {
+			return
+		}
+		if err != nil {
+			setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseFailed, "Failed to delete the instances of the CRD", err)
+			return
+		}
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDraining, fmt.Sprintf("Waiting for %d instances of the CRD to be deleted", instanceCount), nil)
+	default:
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseBlocked, fmt.Sprintf("%d instances of the CRD still exist", instanceCount), nil)
+	}
+}
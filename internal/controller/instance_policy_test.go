@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("Instance policy", func() {
+	Context("When the instances of a CRD are deleted by the instance policy", func() {
+		const resourceName = "draining-policy"
+		const crdName = "drainables.drain.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		// drainable returns an instance of the test CRD with the given name in the default namespace
+		drainable := func(name string) *unstructured.Unstructured {
+			instance := &unstructured.Unstructured{}
+			instance.SetAPIVersion("drain.example.com/v1")
+			instance.SetKind("Drainable")
+			instance.SetName(name)
+			instance.SetNamespace("default")
+			return instance
+		}
+		bystander := types.NamespacedName{Name: "drain-bystander", Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating a CRD, two instances of it and an unrelated ConfigMap")
+			Expect(k8sClient.Create(ctx, newTestCRD("drain.example.com", "drainables", "Drainable", "v1"))).To(Succeed())
+			for _, name := range []string{"first", "second"} {
+				instance := drainable(name)
+				Eventually(func() error {
+					return k8sClient.Create(ctx, instance)
+				}).Should(Succeed())
+			}
+			Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: bystander.Name, Namespace: bystander.Namespace},
+			})).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					InstancePolicy: policiesv1alpha1.InstancePolicyDelete,
+					CRDsVersions:   []policiesv1alpha1.CRDCleanupVersion{{Name: crdName}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: bystander.Name, Namespace: bystander.Namespace},
+			})).To(Succeed())
+		})
+
+		It("should delete the instances before the CRD", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDraining))
+			Expect(policy.Status.Entries[0].DeletedInstances).To(BeEquivalentTo(2))
+
+			By("checking that the instances are gone, but the CRD and other objects are not")
+			for _, name := range []string{"first", "second"} {
+				instance := drainable(name)
+				Eventually(func() bool {
+					return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(instance), instance))
+				}).Should(BeTrue())
+			}
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.DeletionTimestamp).To(BeNil())
+			Expect(k8sClient.Get(ctx, bystander, &corev1.ConfigMap{})).To(Succeed())
+
+			By("deleting the CRD once the instances are gone")
+			Eventually(func() policiesv1alpha1.CRDCleanupPhase {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+				return policy.Status.Entries[0].Phase
+			}).Should(BeElementOf(policiesv1alpha1.CRDCleanupPhaseDeleting, policiesv1alpha1.CRDCleanupPhaseDeleted))
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd))
+			}).Should(BeTrue())
+			Expect(k8sClient.Get(ctx, bystander, &corev1.ConfigMap{})).To(Succeed())
+		})
+	})
+})
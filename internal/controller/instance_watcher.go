@@ -0,0 +1,171 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// instanceEventBufferSize is the number of events that are buffered until the controller picks them up
+const instanceEventBufferSize = 1024
+
+// instanceWatcher starts and stops metadata-only watches on the instances of blocked CRDs,
+// so that a policy is reconciled as soon as an instance of a CRD is created or deleted.
+type instanceWatcher struct {
+	cache  cache.Cache
+	events chan event.GenericEvent
+
+	// syncMu serializes Sync, so an informer is not removed while it is registered again
+	syncMu sync.Mutex
+
+	// watches maps the GVK of every watched CRD to the policies that are blocked by its instances
+	mu      sync.Mutex
+	watches map[schema.GroupVersionKind]map[types.NamespacedName]bool
+}
+
+// newInstanceWatcher creates an instanceWatcher that reads from the given cache
+func newInstanceWatcher(c cache.Cache) *instanceWatcher {
+	return &instanceWatcher{
+		cache:   c,
+		events:  make(chan event.GenericEvent, instanceEventBufferSize),
+		watches: map[schema.GroupVersionKind]map[types.NamespacedName]bool{},
+	}
+}
+
+// Sync makes sure the given policy watches exactly the instances of the given GVKs.
+// Watches that are no longer used by any policy are stopped.
+func (w *instanceWatcher) Sync(ctx context.Context, policy types.NamespacedName, gvks []schema.GroupVersionKind) error {
+	w.syncMu.Lock()
+	defer w.syncMu.Unlock()
+
+	unused, err := w.register(ctx, policy, gvks)
+	if err != nil {
+		return err
+	}
+	// Removing an informer waits for its event handlers, which take w.mu in notify, so it must not be held here
+	for _, gvk := range unused {
+		w.stop(ctx, gvk)
+	}
+	return nil
+}
+
+// register registers the policy for the instances of the given GVKs and unregisters it from all other GVKs.
+// It returns the GVKs that are no longer watched by any policy.
+func (w *instanceWatcher) register(ctx context.Context, policy types.NamespacedName, gvks []schema.GroupVersionKind) ([]schema.GroupVersionKind, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	wanted := make(map[schema.GroupVersionKind]bool, len(gvks))
+	for _, gvk := range gvks {
+		wanted[gvk] = true
+		if err := w.watch(ctx, gvk, policy); err != nil {
+			return nil, err
+		}
+	}
+
+	unused := []schema.GroupVersionKind{}
+	for gvk, policies := range w.watches {
+		if wanted[gvk] || !policies[policy] {
+			continue
+		}
+		delete(policies, policy)
+		if len(policies) == 0 {
+			delete(w.watches, gvk)
+			unused = append(unused, gvk)
+		}
+	}
+	return unused, nil
+}
+
+// watch registers the policy for the instances of the given GVK and starts the watch if needed
+func (w *instanceWatcher) watch(ctx context.Context, gvk schema.GroupVersionKind, policy types.NamespacedName) error {
+	if policies, ok := w.watches[gvk]; ok {
+		policies[policy] = true
+		return nil
+	}
+
+	informer, err := w.cache.GetInformer(ctx, partialObjectMetadata(gvk), cache.BlockUntilSynced(false))
+	if err != nil {
+		return err
+	}
+	// Deleting instances unblocks a policy and creating instances blocks it again. The instances that existed
+	// when the watch started have already been counted by the policy.
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerDetailedFuncs{
+		AddFunc: func(_ interface{}, isInInitialList bool) {
+			if !isInInitialList {
+				w.notify(gvk)
+			}
+		},
+		DeleteFunc: func(interface{}) {
+			w.notify(gvk)
+		},
+	}); err != nil {
+		return err
+	}
+
+	log.FromContext(ctx).Info("Started watching instances of CRD", "GVK", gvk.String())
+	w.watches[gvk] = map[types.NamespacedName]bool{policy: true}
+	return nil
+}
+
+// stop stops the watch on the instances of the given GVK
+func (w *instanceWatcher) stop(ctx context.Context, gvk schema.GroupVersionKind) {
+	log := log.FromContext(ctx)
+	if err := w.cache.RemoveInformer(ctx, partialObjectMetadata(gvk)); err != nil {
+		log.Error(err, "Failed to stop watching instances of CRD", "GVK", gvk.String())
+		return
+	}
+	log.Info("Stopped watching instances of CRD", "GVK", gvk.String())
+}
+
+// notify enqueues every policy that watches the instances of the given GVK. It never blocks the informer: if the
+// controller falls behind and the buffer is full, the event is dropped and the policy is reconciled on its next resync.
+func (w *instanceWatcher) notify(gvk schema.GroupVersionKind) {
+	w.mu.Lock()
+	policies := []types.NamespacedName{}
+	for policy := range w.watches[gvk] {
+		policies = append(policies, policy)
+	}
+	w.mu.Unlock()
+
+	for _, policy := range policies {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetName(policy.Name)
+		obj.SetNamespace(policy.Namespace)
+		select {
+		case w.events <- event.GenericEvent{Object: obj}:
+		default:
+			log.Log.WithName("instance-watcher").Info("Dropped instance event, the policy is reconciled on its next resync", "GVK", gvk.String(), "policy", policy)
+		}
+	}
+}
+
+// partialObjectMetadata returns a metadata-only object of the given GVK
+func partialObjectMetadata(gvk schema.GroupVersionKind) *metav1.PartialObjectMetadata {
+	obj := &metav1.PartialObjectMetadata{}
+	obj.SetGroupVersionKind(gvk)
+	return obj
+}
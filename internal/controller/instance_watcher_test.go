@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Instance watcher", func() {
+	Context("When a policy is blocked by the instances of a CRD", func() {
+		policy := types.NamespacedName{Name: "watching-policy", Namespace: "default"}
+		gvk := schema.GroupVersionKind{Group: "watch.example.com", Version: "v1", Kind: "Watchable"}
+
+		var watcher *instanceWatcher
+		var stop context.CancelFunc
+
+		BeforeEach(func() {
+			By("creating the CRD and starting a cache for the watcher")
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, newTestCRD("watch.example.com", "watchables", "Watchable", "v1")))).To(Succeed())
+
+			c, err := cache.New(cfg, cache.Options{Scheme: scheme.Scheme})
+			Expect(err).NotTo(HaveOccurred())
+			var cacheCtx context.Context
+			cacheCtx, stop = context.WithCancel(ctx)
+			go func() {
+				defer GinkgoRecover()
+				Expect(c.Start(cacheCtx)).To(Succeed())
+			}()
+			Expect(c.WaitForCacheSync(cacheCtx)).To(BeTrue())
+			watcher = newInstanceWatcher(c)
+		})
+
+		AfterEach(func() {
+			Expect(watcher.Sync(ctx, policy, nil)).To(Succeed())
+			Expect(watcher.watches).To(BeEmpty())
+			stop()
+		})
+
+		It("should enqueue the policy when an instance is created or deleted", func() {
+			instance := &unstructured.Unstructured{}
+			instance.SetGroupVersionKind(gvk)
+			instance.SetNamespace("default")
+			instance.SetName("watched")
+
+			Eventually(func() error {
+				return watcher.Sync(ctx, policy, []schema.GroupVersionKind{gvk})
+			}).Should(Succeed())
+
+			By("creating an instance")
+			Eventually(func() error {
+				return k8sClient.Create(ctx, instance)
+			}).Should(Succeed())
+			Eventually(watcher.events).Should(Receive(HaveField("Object.GetName()", policy.Name)))
+
+			By("deleting the instance")
+			Expect(k8sClient.Delete(ctx, instance)).To(Succeed())
+			Eventually(watcher.events).Should(Receive(HaveField("Object.GetName()", policy.Name)))
+		})
+
+		It("should not block the informer if the events are not picked up", func() {
+			Eventually(func() error {
+				return watcher.Sync(ctx, policy, []schema.GroupVersionKind{gvk})
+			}).Should(Succeed())
+			for range instanceEventBufferSize + 1 {
+				watcher.notify(gvk)
+			}
+			Expect(watcher.events).To(HaveLen(instanceEventBufferSize))
+		})
+	})
+})
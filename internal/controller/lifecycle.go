@@ -0,0 +1,166 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// advanceVersionLifecycle moves the version of the entry through the stages of the lifecycle. The version is first
+// deprecated, then no longer served and the stage timestamps are recorded in the entry. It returns true once the
+// version has passed all stages and can be removed. In DryRun mode only the next stage is simulated.
+func (r *CRDCleanupPolicyReconciler) advanceVersionLifecycle(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, entry *policiesv1alpha1.CRDCleanupEntryStatus, lifecycle *policiesv1alpha1.VersionLifecycle, dryRun bool) (bool, error) {
+	if entry.Lifecycle == nil {
+		entry.Lifecycle = &policiesv1alpha1.CRDVersionLifecycleStatus{}
+	}
+	status := entry.Lifecycle
+	now := metav1.Now()
+
+	if status.DeprecatedTime == nil || (status.UnservedTime == nil && now.Time.Before(stageEnd(status.DeprecatedTime, lifecycle.DeprecationPeriod))) {
+		if err := r.setVersionStage(ctx, crd, log, entry.Version, lifecycle.DeprecationWarning, true, dryRun); err != nil {
+			return false, err
+		}
+		if dryRun {
+			return false, nil
+		}
+		if status.DeprecatedTime == nil {
+			status.DeprecatedTime = &now
+		}
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDeprecated,
+			fmt.Sprintf("The version is deprecated and will no longer be served after %s", stageEnd(status.DeprecatedTime, lifecycle.DeprecationPeriod).Format(time.RFC3339)), nil)
+		return false, nil
+	}
+
+	if status.UnservedTime == nil || now.Time.Before(stageEnd(status.UnservedTime, lifecycle.UnservedPeriod)) {
+		if err := r.setVersionStage(ctx, crd, log, entry.Version, lifecycle.DeprecationWarning, false, dryRun); err != nil {
+			return false, err
+		}
+		if dryRun {
+			return false, nil
+		}
+		if status.UnservedTime == nil {
+			status.UnservedTime = &now
+		}
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseUnserved,
+			fmt.Sprintf("The version is no longer served and will be removed after %s", stageEnd(status.UnservedTime, lifecycle.UnservedPeriod).Format(time.RFC3339)), nil)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// setVersionStage marks the version of the CRD as deprecated with the given warning and stops serving it unless
+// served is set. The CRD is only updated if the version is not in the desired stage yet.
+func (r *CRDCleanupPolicyReconciler) setVersionStage(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion, warning string, served, dryRun bool) error {
+	changed := false
+	for i := range crd.Spec.Versions {
+		version := &crd.Spec.Versions[i]
+		if version.Name != crdVersion {
+			continue
+		}
+		if !version.Deprecated {
+			version.Deprecated = true
+			changed = true
+		}
+		if warning != "" && ptr.Deref(version.DeprecationWarning, "") != warning {
+			version.DeprecationWarning = ptr.To(warning)
+			changed = true
+		}
+		if version.Served && !served {
+			version.Served = false
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	opts := []client.UpdateOption{}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.Update(ctx, crd, opts...); err != nil {
+		log.Error(err, "Failed to update the lifecycle of the CRD version", "CRD", crd.GetName(), "Version", crdVersion, "Served", served, "DryRun", dryRun)
+		return err
+	}
+	log.Info("Updated the lifecycle of the CRD version", "CRD", crd.GetName(), "Version", crdVersion, "Deprecated", true, "Served", served, "DryRun", dryRun)
+	return nil
+}
+
+// lifecycleStageDue returns whether the version of the entry has to enter its next lifecycle stage now
+func lifecycleStageDue(entry *policiesv1alpha1.CRDCleanupEntryStatus, lifecycle *policiesv1alpha1.VersionLifecycle) bool {
+	status := entry.Lifecycle
+	if status == nil || status.DeprecatedTime == nil {
+		return true
+	}
+	return status.UnservedTime == nil && !time.Now().Before(stageEnd(status.DeprecatedTime, lifecycle.DeprecationPeriod))
+}
+
+// lifecycleCompleted returns whether the version of the entry has passed all lifecycle stages and can be removed
+func lifecycleCompleted(entry *policiesv1alpha1.CRDCleanupEntryStatus, lifecycle *policiesv1alpha1.VersionLifecycle) bool {
+	status := entry.Lifecycle
+	return status != nil && status.UnservedTime != nil && !time.Now().Before(stageEnd(status.UnservedTime, lifecycle.UnservedPeriod))
+}
+
+// nextLifecycleTransition returns the duration until the next entry of the policy reaches the end of its current
+// lifecycle stage, or zero if no entry is waiting in a stage
+func nextLifecycleTransition(policy cleanupPolicy) time.Duration {
+	lifecycle := policy.GetSpec().VersionLifecycle
+	if lifecycle == nil {
+		return 0
+	}
+
+	var next time.Duration
+	for _, entry := range policy.GetStatus().Entries {
+		if entry.Lifecycle == nil {
+			continue
+		}
+		var end time.Time
+		switch entry.Phase {
+		case policiesv1alpha1.CRDCleanupPhaseDeprecated:
+			end = stageEnd(entry.Lifecycle.DeprecatedTime, lifecycle.DeprecationPeriod)
+		case policiesv1alpha1.CRDCleanupPhaseUnserved:
+			end = stageEnd(entry.Lifecycle.UnservedTime, lifecycle.UnservedPeriod)
+		default:
+			continue
+		}
+		// Entries whose stage has already ended are processed right away
+		wait := max(time.Until(end), time.Second)
+		if next == 0 || wait < next {
+			next = wait
+		}
+	}
+	return next
+}
+
+// stageEnd returns the time a lifecycle stage that started at the given time ends after the given period
+func stageEnd(start *metav1.Time, period *metav1.Duration) time.Time {
+	if period == nil {
+		return start.Time
+	}
+	return start.Add(period.Duration)
+}
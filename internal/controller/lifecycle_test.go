@@ -0,0 +1,345 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("Version removal", func() {
+	Context("When removing versions of a CRD", func() {
+		const resourceName = "version-policy"
+		const crdName = "versionedsamples.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a CRD stored in v2 that also serves v1")
+			Expect(k8sClient.Create(ctx, newTestCRD("example.com", "versionedsamples", "VersionedSample", "v2", "v1"))).To(Succeed())
+
+			resource := &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{
+						{Name: crdName, Version: "v1"},
+						{Name: crdName, Version: "v2"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+		})
+
+		It("should only remove versions that are not stored", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(2))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDeleted))
+			Expect(policy.Status.Entries[1].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseBlocked))
+			Expect(policy.Status.Entries[1].BlockedVersion).To(Equal("v2"))
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.Spec.Versions).To(HaveLen(1))
+			Expect(crd.Spec.Versions[0].Name).To(Equal("v2"))
+		})
+	})
+
+	Context("When removing a version that objects are still stored in", func() {
+		const resourceName = "migration-policy"
+		const crdName = "migratedsamples.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a CRD stored in v2 with objects still stored in v1")
+			crd := newTestCRD("example.com", "migratedsamples", "MigratedSample", "v2", "v1")
+			Expect(k8sClient.Create(ctx, crd)).To(Succeed())
+			crd.Status.StoredVersions = []string{"v1", "v2"}
+			Expect(k8sClient.Status().Update(ctx, crd)).To(Succeed())
+
+			instance := &unstructured.Unstructured{}
+			instance.SetAPIVersion("example.com/v2")
+			instance.SetKind("MigratedSample")
+			instance.SetName("migrated-sample")
+			instance.SetNamespace("default")
+			Eventually(func() error {
+				return k8sClient.Create(ctx, instance)
+			}).Should(Succeed())
+
+			resource := &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: crdName, Version: "v1"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+		})
+
+		It("should migrate the stored objects before removing the version", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDeleted))
+			Expect(policy.Status.Entries[0].Migration).NotTo(BeNil())
+			Expect(policy.Status.Entries[0].Migration.StorageVersion).To(Equal("v2"))
+			Expect(policy.Status.Entries[0].Migration.MigratedObjects).To(BeEquivalentTo(1))
+			Expect(policy.Status.Entries[0].Migration.CompletionTime).NotTo(BeNil())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.Status.StoredVersions).To(Equal([]string{"v2"}))
+			Expect(crd.Spec.Versions).To(HaveLen(1))
+			Expect(crd.Spec.Versions[0].Name).To(Equal("v2"))
+		})
+	})
+
+	Context("When removing a version with a staged lifecycle", func() {
+		const resourceName = "lifecycle-policy"
+		const crdName = "stagedsamples.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a CRD stored in v2 that also serves v1")
+			Expect(k8sClient.Create(ctx, newTestCRD("example.com", "stagedsamples", "StagedSample", "v2", "v1"))).To(Succeed())
+
+			resource := &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: crdName, Version: "v1"}},
+					VersionLifecycle: &policiesv1alpha1.VersionLifecycle{
+						DeprecationPeriod:  &metav1.Duration{Duration: time.Hour},
+						UnservedPeriod:     &metav1.Duration{Duration: time.Hour},
+						DeprecationWarning: "v1 is going away, use v2",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+		})
+
+		It("should deprecate and unserve the version before removing it", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			By("deprecating the version")
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("<=", time.Hour))
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDeprecated))
+			Expect(policy.Status.Entries[0].Lifecycle.DeprecatedTime).NotTo(BeNil())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.Spec.Versions[1].Deprecated).To(BeTrue())
+			Expect(crd.Spec.Versions[1].DeprecationWarning).To(Equal(ptr.To("v1 is going away, use v2")))
+			Expect(crd.Spec.Versions[1].Served).To(BeTrue())
+
+			By("unserving the version once the deprecation period passed")
+			policy.Status.Entries[0].Lifecycle.DeprecatedTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			Expect(k8sClient.Status().Update(ctx, policy)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseUnserved))
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.Spec.Versions[1].Served).To(BeFalse())
+
+			By("removing the version once the unserved period passed")
+			policy.Status.Entries[0].Lifecycle.UnservedTime = &metav1.Time{Time: time.Now().Add(-2 * time.Hour)}
+			Expect(k8sClient.Status().Update(ctx, policy)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDeleted))
+			Expect(policy.Status.Entries[0].Lifecycle.RemovedTime).NotTo(BeNil())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.Spec.Versions).To(HaveLen(1))
+		})
+	})
+
+	Context("When removing the storage version of a CRD", func() {
+		const resourceName = "handover-policy"
+		const crdName = "handedoversamples.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a CRD stored in v1 that also serves v1beta1 and v2")
+			Expect(k8sClient.Create(ctx, newTestCRD("example.com", "handedoversamples", "HandedOverSample", "v1", "v1beta1", "v2"))).To(Succeed())
+
+			resource := &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+				},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: crdName, Version: "v1"}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+		})
+
+		It("should hand the storage version over to the successor before removing it", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			By("handing the storage version over to the served version with the highest priority")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseMigrating))
+			Expect(policy.Status.Entries[0].Migration.StorageVersion).To(Equal("v2"))
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(storageVersion(crd)).To(Equal("v2"))
+
+			By("waiting for the API servers to pick up the new storage version")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseMigrating))
+			Expect(policy.Status.Entries[0].Migration.StartTime).To(BeNil())
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.Status.StoredVersions).To(ContainElement("v1"))
+
+			policy.Status.Entries[0].Migration.HandOverTime = &metav1.Time{Time: time.Now().Add(-2 * storageVersionSettlePeriod)}
+			Expect(k8sClient.Status().Update(ctx, policy)).To(Succeed())
+
+			By("migrating the stored objects and removing the previous storage version")
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDeleted))
+			Expect(policy.Status.Entries[0].Migration.PreviousStorageVersion).To(Equal("v1"))
+
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.Status.StoredVersions).To(Equal([]string{"v2"}))
+			Expect(crd.Spec.Versions).To(HaveLen(2))
+		})
+	})
+})
@@ -0,0 +1,324 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	storagemigrationv1alpha1 "k8s.io/api/storagemigration/v1alpha1"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilversion "k8s.io/apimachinery/pkg/version"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// handOverStorageVersion makes the successor the storage version of the CRD instead of the given version.
+// The objects stored in the previous storage version have to be migrated before the version can be removed.
+func (r *CRDCleanupPolicyReconciler) handOverStorageVersion(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion, successor string, dryRun bool) error {
+	if !slices.ContainsFunc(crd.Spec.Versions, func(v v1.CustomResourceDefinitionVersion) bool { return v.Name == successor }) {
+		return fmt.Errorf("storage successor %s is not a version of CRD %s", successor, crd.GetName())
+	}
+	for i := range crd.Spec.Versions {
+		crd.Spec.Versions[i].Storage = crd.Spec.Versions[i].Name == successor
+	}
+
+	opts := []client.UpdateOption{}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	if err := r.Update(ctx, crd, opts...); err != nil {
+		log.Error(err, "Failed to hand over the storage version of CRD", "CRD", crd.GetName(), "Version", crdVersion, "Successor", successor, "DryRun", dryRun)
+		return err
+	}
+	log.Info("Handed over the storage version of CRD", "CRD", crd.GetName(), "Version", crdVersion, "Successor", successor, "DryRun", dryRun)
+	return nil
+}
+
+// storageSuccessor returns the version that replaces the given storage version of the CRD. The requested successor
+// is used if it is another version of the CRD, otherwise the served version with the highest priority is chosen.
+// It returns an empty string if there is no suitable successor.
+func storageSuccessor(crd *v1.CustomResourceDefinition, crdVersion, requested string) string {
+	if requested != "" {
+		if requested != crdVersion && slices.ContainsFunc(crd.Spec.Versions, func(v v1.CustomResourceDefinitionVersion) bool { return v.Name == requested }) {
+			return requested
+		}
+		return ""
+	}
+
+	successor := ""
+	for _, version := range crd.Spec.Versions {
+		if version.Name == crdVersion || !version.Served {
+			continue
+		}
+		if successor == "" || utilversion.CompareKubeAwareVersionStrings(version.Name, successor) > 0 {
+			successor = version.Name
+		}
+	}
+	return successor
+}
+
+// storageVersionSettlePeriod is the time API servers are given to pick up a new storage version of a CRD
+const storageVersionSettlePeriod = time.Minute
+
+// storageVersionSettled returns whether the objects of the CRD are stored in the storage version the migration
+// handed over to. The new storage version has to be recorded in the stored versions of the CRD and the settle
+// period has to have passed since the handover.
+func storageVersionSettled(crd *v1.CustomResourceDefinition, migration *policiesv1alpha1.CRDMigrationStatus, now time.Time) bool {
+	if storageVersion(crd) != migration.StorageVersion || !slices.Contains(crd.Status.StoredVersions, migration.StorageVersion) {
+		return false
+	}
+	return migration.HandOverTime != nil && !now.Before(migration.HandOverTime.Add(storageVersionSettlePeriod))
+}
+
+// nextStorageVersionSettled returns the duration until the first handed over storage version of the policy
+// settles, or 0 if no entry waits for one
+func nextStorageVersionSettled(policy cleanupPolicy) time.Duration {
+	var next time.Duration
+	for _, entry := range policy.GetStatus().Entries {
+		migration := entry.Migration
+		if entry.Phase != policiesv1alpha1.CRDCleanupPhaseMigrating || migration == nil || migration.HandOverTime == nil || migration.StartTime != nil {
+			continue
+		}
+		wait := max(time.Until(migration.HandOverTime.Add(storageVersionSettlePeriod)), time.Second)
+		if next == 0 || wait < next {
+			next = wait
+		}
+	}
+	return next
+}
+
+// migrateStoredVersion migrates every object of the CRD to its storage version and removes the given version
+// from the stored versions of the CRD afterwards. Progress and failures are recorded in the migration status,
+// which has no completion time as long as the migration is still running.
+// If the cluster serves the StorageVersionMigration API, the migration is delegated to it.
+func (r *CRDCleanupPolicyReconciler) migrateStoredVersion(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion string, migration *policiesv1alpha1.CRDMigrationStatus, dryRun bool) error {
+	available, err := r.storageVersionMigrationAvailable()
+	if err != nil {
+		log.Error(err, "Failed to discover the StorageVersionMigration API")
+		return err
+	}
+	if available {
+		return r.migrateWithStorageVersionMigration(ctx, crd, log, crdVersion, migration, dryRun)
+	}
+	return r.migrateObjects(ctx, crd, log, crdVersion, migration, dryRun)
+}
+
+// migrateObjects rewrites every object of the CRD in its storage version with a no-op update
+// and removes the given version from the stored versions of the CRD afterwards
+func (r *CRDCleanupPolicyReconciler) migrateObjects(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion string, migration *policiesv1alpha1.CRDMigrationStatus, dryRun bool) error {
+	storage := storageVersion(crd)
+	now := metav1.Now()
+	*migration = policiesv1alpha1.CRDMigrationStatus{
+		StorageVersion:         storage,
+		PreviousStorageVersion: migration.PreviousStorageVersion,
+		HandOverTime:           migration.HandOverTime,
+		StartTime:              &now,
+	}
+	log.Info("Migrating stored objects of CRD", "CRD", crd.GetName(), "FromVersion", crdVersion, "StorageVersion", storage, "DryRun", dryRun)
+
+	mapping, err := r.instanceMapping(crd, storage)
+	if err != nil {
+		log.Error(err, "Failed to resolve instances of CRD", "CRD", crd.GetName())
+		return err
+	}
+
+	opts := []client.UpdateOption{}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	var lastErr error
+	instances := &unstructured.UnstructuredList{}
+	instances.SetGroupVersionKind(mapping.GroupVersionKind.GroupVersion().WithKind(mapping.GroupVersionKind.Kind + "List"))
+	for {
+		if err := r.apiReader().List(ctx, instances, client.Limit(instanceListPageSize), client.Continue(instances.GetContinue())); err != nil {
+			log.Error(err, "Failed to list instances of CRD", "CRD", crd.GetName())
+			return err
+		}
+		for i := range instances.Items {
+			instance := &instances.Items[i]
+			// A no-op update makes the API server re-encode the object in the storage version.
+			// Objects that were changed or deleted in the meantime do not need to be rewritten anymore.
+			if err := r.Update(ctx, instance, opts...); err != nil && !errors.IsConflict(err) && !errors.IsNotFound(err) {
+				log.Error(err, "Failed to migrate object of CRD", "CRD", crd.GetName(), "Namespace", instance.GetNamespace(), "Name", instance.GetName())
+				migration.FailedObjects++
+				lastErr = instanceAccessError(err, crd)
+				continue
+			}
+			migration.MigratedObjects++
+		}
+		if instances.GetContinue() == "" {
+			break
+		}
+	}
+
+	if lastErr != nil {
+		return fmt.Errorf("failed to migrate %d objects of CRD %s: %w", migration.FailedObjects, crd.GetName(), lastErr)
+	}
+
+	if err := r.removeStoredVersion(ctx, crd, log, crdVersion, dryRun); err != nil {
+		return err
+	}
+	completed := metav1.Now()
+	migration.CompletionTime = &completed
+	log.Info("Successfully migrated stored objects of CRD", "CRD", crd.GetName(), "MigratedObjects", migration.MigratedObjects, "DryRun", dryRun)
+	return nil
+}
+
+// migrateWithStorageVersionMigration creates a StorageVersionMigration for the resource of the CRD and removes the
+// given version from the stored versions of the CRD once the migration succeeded. A failed migration is deleted,
+// so that it is recreated on the next attempt.
+func (r *CRDCleanupPolicyReconciler) migrateWithStorageVersionMigration(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion string, migration *policiesv1alpha1.CRDMigrationStatus, dryRun bool) error {
+	storage := storageVersion(crd)
+	mapping, err := r.instanceMapping(crd, storage)
+	if err != nil {
+		log.Error(err, "Failed to resolve instances of CRD", "CRD", crd.GetName())
+		return err
+	}
+
+	svm := &storagemigrationv1alpha1.StorageVersionMigration{}
+	svm.SetName(storageVersionMigrationName(crd, crdVersion))
+	if migration.StorageVersionMigration != svm.GetName() || migration.StartTime == nil {
+		now := metav1.Now()
+		*migration = policiesv1alpha1.CRDMigrationStatus{
+			StorageVersion:          storage,
+			PreviousStorageVersion:  migration.PreviousStorageVersion,
+			HandOverTime:            migration.HandOverTime,
+			StorageVersionMigration: svm.GetName(),
+			StartTime:               &now,
+		}
+	}
+
+	if dryRun {
+		// A StorageVersionMigration created with dryRun=All never runs, so only its creation can be verified
+		svm.Spec.Resource = storagemigrationv1alpha1.GroupVersionResource{
+			Group:    mapping.Resource.Group,
+			Version:  mapping.Resource.Version,
+			Resource: mapping.Resource.Resource,
+		}
+		if err := r.Create(ctx, svm, client.DryRunAll); err != nil && !errors.IsAlreadyExists(err) {
+			log.Error(err, "Failed to create StorageVersionMigration", "CRD", crd.GetName(), "StorageVersionMigration", svm.GetName(), "DryRun", dryRun)
+			return err
+		}
+		return nil
+	}
+
+	if err := r.apiReader().Get(ctx, client.ObjectKeyFromObject(svm), svm); err != nil {
+		if !errors.IsNotFound(err) {
+			log.Error(err, "Failed to fetch StorageVersionMigration", "CRD", crd.GetName(), "StorageVersionMigration", svm.GetName())
+			return err
+		}
+		svm.Spec.Resource = storagemigrationv1alpha1.GroupVersionResource{
+			Group:    mapping.Resource.Group,
+			Version:  mapping.Resource.Version,
+			Resource: mapping.Resource.Resource,
+		}
+		if err := r.Create(ctx, svm); err != nil {
+			log.Error(err, "Failed to create StorageVersionMigration", "CRD", crd.GetName(), "StorageVersionMigration", svm.GetName())
+			return err
+		}
+		log.Info("Created StorageVersionMigration for CRD", "CRD", crd.GetName(), "FromVersion", crdVersion, "StorageVersionMigration", svm.GetName())
+		return nil
+	}
+
+	switch {
+	case hasMigrationCondition(svm, storagemigrationv1alpha1.MigrationSucceeded):
+		if err := r.removeStoredVersion(ctx, crd, log, crdVersion, dryRun); err != nil {
+			return err
+		}
+		// The migration is deleted so that a later migration of the same version is not considered complete
+		if err := r.Delete(ctx, svm); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Failed to delete StorageVersionMigration", "StorageVersionMigration", svm.GetName())
+		}
+		completed := metav1.Now()
+		migration.CompletionTime = &completed
+		log.Info("StorageVersionMigration of CRD succeeded", "CRD", crd.GetName(), "StorageVersionMigration", svm.GetName())
+		return nil
+	case hasMigrationCondition(svm, storagemigrationv1alpha1.MigrationFailed):
+		if err := r.Delete(ctx, svm); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Failed to delete StorageVersionMigration", "StorageVersionMigration", svm.GetName())
+			return err
+		}
+		return fmt.Errorf("StorageVersionMigration %s of CRD %s failed", svm.GetName(), crd.GetName())
+	default:
+		log.Info("StorageVersionMigration of CRD is still running", "CRD", crd.GetName(), "StorageVersionMigration", svm.GetName())
+		return nil
+	}
+}
+
+// storageVersionMigrationAvailable returns true if the cluster serves the StorageVersionMigration API
+func (r *CRDCleanupPolicyReconciler) storageVersionMigrationAvailable() (bool, error) {
+	gvk := storagemigrationv1alpha1.SchemeGroupVersion.WithKind("StorageVersionMigration")
+	if _, err := r.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// storageVersionMigrationName returns the name of the StorageVersionMigration that migrates the objects
+// of the CRD away from the given version
+func storageVersionMigrationName(crd *v1.CustomResourceDefinition, crdVersion string) string {
+	suffix := "-" + crdVersion
+	name := crd.GetName()
+	if len(name)+len(suffix) > 253 {
+		name = name[:253-len(suffix)]
+	}
+	return name + suffix
+}
+
+// hasMigrationCondition returns true if the StorageVersionMigration has a true condition of the given type
+func hasMigrationCondition(svm *storagemigrationv1alpha1.StorageVersionMigration, conditionType storagemigrationv1alpha1.MigrationConditionType) bool {
+	for _, condition := range svm.Status.Conditions {
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// removeStoredVersion removes the given version from the status.storedVersions of the CRD
+func (r *CRDCleanupPolicyReconciler) removeStoredVersion(ctx context.Context, crd *v1.CustomResourceDefinition, log logr.Logger, crdVersion string, dryRun bool) error {
+	original := crd.DeepCopy()
+	crd.Status.StoredVersions = slices.DeleteFunc(slices.Clone(crd.Status.StoredVersions), func(v string) bool {
+		return v == crdVersion
+	})
+
+	opts := []client.SubResourcePatchOption{}
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+	// The optimistic lock makes sure the storage version did not change since the objects were migrated
+	if err := r.Status().Patch(ctx, crd, client.MergeFromWithOptions(original, client.MergeFromWithOptimisticLock{}), opts...); err != nil {
+		log.Error(err, "Failed to remove stored version from CRD", "CRD", crd.GetName(), "Version", crdVersion)
+		return err
+	}
+	log.Info("Removed stored version from CRD", "CRD", crd.GetName(), "Version", crdVersion, "DryRun", dryRun)
+	return nil
+}
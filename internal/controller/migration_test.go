@@ -0,0 +1,169 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	storagemigrationv1alpha1 "k8s.io/api/storagemigration/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// The StorageVersionMigration API is alpha and not served by envtest, so these tests run against a fake client
+var _ = Describe("StorageVersionMigration", func() {
+	const crdName = "svmsamples.example.com"
+	svmKey := types.NamespacedName{Name: crdName + "-v1"}
+
+	var crd *apiextensionsv1.CustomResourceDefinition
+	var migration *policiesv1alpha1.CRDMigrationStatus
+
+	// newReconciler returns a reconciler whose REST mapper knows the instances of the CRD and, if served is set,
+	// the StorageVersionMigration API
+	newReconciler := func(served bool, objs ...client.Object) *CRDCleanupPolicyReconciler {
+		mapper := meta.NewDefaultRESTMapper(nil)
+		mapper.Add(schema.GroupVersionKind{Group: "example.com", Version: "v2", Kind: "SVMSample"}, meta.RESTScopeNamespace)
+		if served {
+			mapper.Add(storagemigrationv1alpha1.SchemeGroupVersion.WithKind("StorageVersionMigration"), meta.RESTScopeRoot)
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithRESTMapper(mapper).
+			WithObjects(append(objs, crd)...).
+			WithStatusSubresource(&apiextensionsv1.CustomResourceDefinition{}).
+			Build()
+		return &CRDCleanupPolicyReconciler{Client: c, Scheme: scheme.Scheme}
+	}
+
+	// fetchCRD returns the current CRD of the reconciler
+	fetchCRD := func(r *CRDCleanupPolicyReconciler) *apiextensionsv1.CustomResourceDefinition {
+		current := &apiextensionsv1.CustomResourceDefinition{}
+		Expect(r.Get(ctx, types.NamespacedName{Name: crdName}, current)).To(Succeed())
+		return current
+	}
+
+	// finishedMigration returns the StorageVersionMigration of the CRD with a true condition of the given type
+	finishedMigration := func(conditionType storagemigrationv1alpha1.MigrationConditionType) *storagemigrationv1alpha1.StorageVersionMigration {
+		svm := &storagemigrationv1alpha1.StorageVersionMigration{}
+		svm.SetName(svmKey.Name)
+		svm.Status.Conditions = []storagemigrationv1alpha1.MigrationCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		return svm
+	}
+
+	BeforeEach(func() {
+		crd = newTestCRD("example.com", "svmsamples", "SVMSample", "v2", "v1")
+		crd.Status.StoredVersions = []string{"v1", "v2"}
+		migration = &policiesv1alpha1.CRDMigrationStatus{StorageVersion: "v2", PreviousStorageVersion: "v1"}
+	})
+
+	It("should create a StorageVersionMigration for the resource of the CRD", func() {
+		r := newReconciler(true)
+		Expect(r.migrateStoredVersion(ctx, fetchCRD(r), log.FromContext(ctx), "v1", migration, false)).To(Succeed())
+
+		svm := &storagemigrationv1alpha1.StorageVersionMigration{}
+		Expect(r.Get(ctx, svmKey, svm)).To(Succeed())
+		Expect(svm.Spec.Resource).To(Equal(storagemigrationv1alpha1.GroupVersionResource{Group: "example.com", Version: "v2", Resource: "svmsamples"}))
+		Expect(migration.StorageVersionMigration).To(Equal(svmKey.Name))
+		Expect(migration.StartTime).NotTo(BeNil())
+		Expect(migration.CompletionTime).To(BeNil())
+	})
+
+	It("should only verify the creation in DryRun mode", func() {
+		r := newReconciler(true)
+		Expect(r.migrateStoredVersion(ctx, fetchCRD(r), log.FromContext(ctx), "v1", migration, true)).To(Succeed())
+		Expect(errors.IsNotFound(r.Get(ctx, svmKey, &storagemigrationv1alpha1.StorageVersionMigration{}))).To(BeTrue())
+	})
+
+	It("should wait for a running StorageVersionMigration", func() {
+		svm := &storagemigrationv1alpha1.StorageVersionMigration{}
+		svm.SetName(svmKey.Name)
+		r := newReconciler(true, svm)
+		Expect(r.migrateStoredVersion(ctx, fetchCRD(r), log.FromContext(ctx), "v1", migration, false)).To(Succeed())
+
+		Expect(migration.CompletionTime).To(BeNil())
+		Expect(fetchCRD(r).Status.StoredVersions).To(ConsistOf("v1", "v2"))
+	})
+
+	It("should remove the stored version once the StorageVersionMigration succeeded", func() {
+		r := newReconciler(true, finishedMigration(storagemigrationv1alpha1.MigrationSucceeded))
+		Expect(r.migrateStoredVersion(ctx, fetchCRD(r), log.FromContext(ctx), "v1", migration, false)).To(Succeed())
+
+		Expect(migration.CompletionTime).NotTo(BeNil())
+		Expect(fetchCRD(r).Status.StoredVersions).To(ConsistOf("v2"))
+		Expect(errors.IsNotFound(r.Get(ctx, svmKey, &storagemigrationv1alpha1.StorageVersionMigration{}))).To(BeTrue())
+	})
+
+	It("should delete a failed StorageVersionMigration so that it is recreated", func() {
+		r := newReconciler(true, finishedMigration(storagemigrationv1alpha1.MigrationFailed))
+		err := r.migrateStoredVersion(ctx, fetchCRD(r), log.FromContext(ctx), "v1", migration, false)
+		Expect(err).To(MatchError(ContainSubstring("failed")))
+
+		Expect(migration.CompletionTime).To(BeNil())
+		Expect(fetchCRD(r).Status.StoredVersions).To(ConsistOf("v1", "v2"))
+		Expect(errors.IsNotFound(r.Get(ctx, svmKey, &storagemigrationv1alpha1.StorageVersionMigration{}))).To(BeTrue())
+
+		By("recreating the migration on the next attempt")
+		Expect(r.migrateStoredVersion(ctx, fetchCRD(r), log.FromContext(ctx), "v1", migration, false)).To(Succeed())
+		Expect(r.Get(ctx, svmKey, &storagemigrationv1alpha1.StorageVersionMigration{})).To(Succeed())
+	})
+
+	It("should rewrite the objects itself if the StorageVersionMigration API is not served", func() {
+		r := newReconciler(false)
+		Expect(r.migrateStoredVersion(ctx, fetchCRD(r), log.FromContext(ctx), "v1", migration, false)).To(Succeed())
+
+		Expect(migration.StorageVersionMigration).To(BeEmpty())
+		Expect(migration.CompletionTime).NotTo(BeNil())
+		Expect(fetchCRD(r).Status.StoredVersions).To(ConsistOf("v2"))
+	})
+
+	It("should keep the time the storage version was handed over", func() {
+		handOverTime := metav1.NewTime(time.Now().Add(-time.Hour))
+		migration.HandOverTime = &handOverTime
+		r := newReconciler(true)
+		Expect(r.migrateStoredVersion(ctx, fetchCRD(r), log.FromContext(ctx), "v1", migration, false)).To(Succeed())
+		Expect(migration.HandOverTime).To(Equal(&handOverTime))
+	})
+
+	It("should only consider a handed over storage version settled once the API servers had time to pick it up", func() {
+		now := time.Now()
+		crd.Status.StoredVersions = []string{"v1"}
+		migration.HandOverTime = &metav1.Time{Time: now.Add(-2 * storageVersionSettlePeriod)}
+		Expect(storageVersionSettled(crd, migration, now)).To(BeFalse())
+
+		crd.Status.StoredVersions = []string{"v1", "v2"}
+		Expect(storageVersionSettled(crd, migration, now)).To(BeTrue())
+
+		migration.HandOverTime = &metav1.Time{Time: now.Add(-storageVersionSettlePeriod / 2)}
+		Expect(storageVersionSettled(crd, migration, now)).To(BeFalse())
+
+		migration.HandOverTime = nil
+		Expect(storageVersionSettled(crd, migration, now)).To(BeFalse())
+	})
+})
@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/protection"
+)
+
+// acknowledgeProtection returns whether the entry may be processed. Entries for protected CRDs are denied unless
+// they acknowledge the protection, in which case the acknowledgement is recorded in the status of the entry.
+func acknowledgeProtection(policy cleanupPolicy, entry *policiesv1alpha1.CRDCleanupEntryStatus, patterns []string, log logr.Logger) bool {
+	pattern := protection.Match(patterns, entry.Name)
+	if pattern == "" {
+		entry.Protection = nil
+		return true
+	}
+
+	crdVersion := specEntry(policy, entry)
+	if crdVersion == nil || crdVersion.AcknowledgeProtected == nil {
+		log.Info("CRD is protected", "CRD", entry.Name, "Pattern", pattern)
+		entry.Protection = nil
+		setEntryPhase(entry, policiesv1alpha1.CRDCleanupPhaseDenied, fmt.Sprintf("The CRD is protected by pattern %s and the entry does not acknowledge it", pattern), nil)
+		return false
+	}
+
+	reason := crdVersion.AcknowledgeProtected.Reason
+	if entry.Protection == nil || entry.Protection.Pattern != pattern || entry.Protection.Reason != reason {
+		user, _ := requester(policy)
+		log.Info("Removal of protected CRD acknowledged", "CRD", entry.Name, "Pattern", pattern, "Reason", reason, "AcknowledgedBy", user)
+		entry.Protection = &policiesv1alpha1.CRDProtectionStatus{
+			Pattern:          pattern,
+			Reason:           reason,
+			AcknowledgedBy:   user,
+			AcknowledgedTime: metav1.Now(),
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("Protected CRDs", func() {
+	Context("When a policy targets protected CRDs", func() {
+		const resourceName = "protected-policy"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a policy for a protected CRD with and without acknowledgement")
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        resourceName,
+					Namespace:   "default",
+					Annotations: map[string]string{policiesv1alpha1.RequestedByAnnotation: "jane"},
+				},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{
+						{Name: "gateways.gateway.networking.k8s.io"},
+						{
+							Name:                 "httproutes.gateway.networking.k8s.io",
+							AcknowledgeProtected: &policiesv1alpha1.ProtectionAcknowledgement{Reason: "Replaced by the vendor CRD"},
+						},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+		})
+
+		It("should only process entries that acknowledge the protection", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				ProtectedCRDs: []string{"*.k8s.io"},
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(2))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseDenied))
+			Expect(policy.Status.Entries[1].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseNotFound))
+			Expect(policy.Status.Entries[1].Protection).NotTo(BeNil())
+			Expect(policy.Status.Entries[1].Protection.Pattern).To(Equal("*.k8s.io"))
+			Expect(policy.Status.Entries[1].Protection.AcknowledgedBy).To(Equal("jane"))
+		})
+	})
+})
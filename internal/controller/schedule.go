@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/schedule"
+)
+
+// hold explains why the operator may not change the cluster on behalf of a policy right now.
+// Entries are still evaluated, but move to the phase of the hold instead of being changed.
+type hold struct {
+	phase   policiesv1alpha1.CRDCleanupPhase
+	message string
+}
+
+// apply moves the entry to the phase of the hold, describing the change that was deferred
+func (h *hold) apply(entry *policiesv1alpha1.CRDCleanupEntryStatus, change string) {
+	setEntryPhase(entry, h.phase, fmt.Sprintf("%s: %s", h.message, change), nil)
+}
+
+// holdFor returns why the policy may not change the cluster right now, or nil if it may. The emergency stop and
+// a suspended policy also hold policies in DryRun mode, since their dry-run calls are still deletions and updates.
+// The schedule does not, since policies in DryRun mode do not change the cluster anyway.
+func (r *CRDCleanupPolicyReconciler) holdFor(ctx context.Context, policy cleanupPolicy) *hold {
+	if reason := r.EmergencyStop.Reason(ctx, r.Client); reason != "" {
+		return &hold{phase: policiesv1alpha1.CRDCleanupPhaseSuspended, message: reason}
+	}
+	if policy.GetSpec().Suspend {
+		return &hold{phase: policiesv1alpha1.CRDCleanupPhaseSuspended, message: "The policy is suspended"}
+	}
+	if isDryRun(policy) {
+		return nil
+	}
+	open, next, err := maintenanceWindow(policy, time.Now())
+	switch {
+	case err != nil:
+		return &hold{phase: policiesv1alpha1.CRDCleanupPhaseScheduled, message: fmt.Sprintf("The schedule is invalid: %v", err)}
+	case open:
+		return nil
+	case next.IsZero():
+		return &hold{phase: policiesv1alpha1.CRDCleanupPhaseScheduled, message: "No maintenance window opens anymore"}
+	default:
+		return &hold{phase: policiesv1alpha1.CRDCleanupPhaseScheduled,
+			message: fmt.Sprintf("Waiting for the maintenance window opening at %s", next.Format(time.RFC3339))}
+	}
+}
+
+// maintenanceWindow returns whether the schedule of the policy allows changes at the given time and, if it does
+// not, the time the next maintenance window opens. Policies without a schedule are always open.
+func maintenanceWindow(policy cleanupPolicy, now time.Time) (bool, time.Time, error) {
+	spec := policy.GetSpec().Schedule
+	if spec == nil {
+		return true, time.Time{}, nil
+	}
+	parsed, err := schedule.Parse(spec)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	open, next := parsed.Open(now)
+	return open, next, nil
+}
+
+// nextWindowOpening returns the duration until the next maintenance window opens if entries wait for it,
+// or zero otherwise
+func nextWindowOpening(policy cleanupPolicy) time.Duration {
+	if countEntriesInPhase(policy, policiesv1alpha1.CRDCleanupPhaseScheduled) == 0 {
+		return 0
+	}
+	open, next, err := maintenanceWindow(policy, time.Now())
+	if err != nil || open || next.IsZero() {
+		return 0
+	}
+	return max(time.Until(next), time.Second)
+}
+
+// maintenanceWindowCondition returns whether the schedule of the policy allows changes as a condition and records
+// the time the next maintenance window opens in the status
+func maintenanceWindowCondition(policy cleanupPolicy) metav1.Condition {
+	condition := metav1.Condition{Type: policiesv1alpha1.ConditionTypeInMaintenanceWindow}
+	policy.GetStatus().NextMaintenanceWindow = nil
+
+	open, next, err := maintenanceWindow(policy, time.Now())
+	switch {
+	case err != nil:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidSchedule"
+		condition.Message = err.Error()
+	case policy.GetSpec().Schedule == nil:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "NoSchedule"
+		condition.Message = "The policy has no schedule"
+	case open:
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "WindowOpen"
+		condition.Message = "Changes are allowed by the schedule"
+	case next.IsZero():
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ScheduleEnded"
+		condition.Message = "No maintenance window opens anymore"
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "OutsideWindow"
+		condition.Message = fmt.Sprintf("The next maintenance window opens at %s", next.Format(time.RFC3339))
+		policy.GetStatus().NextMaintenanceWindow = &metav1.Time{Time: next}
+	}
+	return condition
+}
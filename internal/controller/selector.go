@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/protection"
+)
+
+// selectorIndexValue is indexed for policies with selectors or deprecated versions, so every CRD event enqueues them.
+// It can never collide with the name of a CRD.
+const selectorIndexValue = "*"
+
+// hasSelectors returns true if the policy selects CRDs, targets deprecated versions or collects selected unused CRDs
+func hasSelectors(policy cleanupPolicy) bool {
+	spec := policy.GetSpec()
+	return len(spec.Selectors) > 0 || spec.DeprecatedVersions != nil || spec.UnusedCRDs != nil && spec.UnusedCRDs.Selector != nil
+}
+
+// selectEntries expands the selectors, deprecated versions and unused CRD selector of the policy into entries for
+// the CRDs and CRD versions they currently select, sorted by their key. Protected CRDs are never selected, since
+// only entries naming them explicitly can acknowledge the protection.
+func (r *CRDCleanupPolicyReconciler) selectEntries(ctx context.Context, policy cleanupPolicy, log logr.Logger) ([]policiesv1alpha1.CRDCleanupEntryStatus, error) {
+	spec := policy.GetSpec()
+	if !hasSelectors(policy) {
+		return nil, nil
+	}
+
+	crds := &v1.CustomResourceDefinitionList{}
+	if err := r.List(ctx, crds); err != nil {
+		log.Error(err, "Failed to list CRDs to expand the selectors")
+		return nil, err
+	}
+	crds.Items = slices.DeleteFunc(crds.Items, func(crd v1.CustomResourceDefinition) bool {
+		return protection.Match(r.ProtectedCRDs, crd.Name) != ""
+	})
+
+	selectors := spec.Selectors
+	if spec.UnusedCRDs != nil && spec.UnusedCRDs.Selector != nil {
+		selectors = append(slices.Clone(selectors), *spec.UnusedCRDs.Selector)
+	}
+
+	selected := map[string]policiesv1alpha1.CRDCleanupEntryStatus{}
+	for _, selector := range selectors {
+		matches, err := selectorMatcher(&selector)
+		if err != nil {
+			log.Error(err, "Invalid label selector")
+			return nil, err
+		}
+		for _, crd := range crds.Items {
+			if matches(&crd) {
+				selected[crd.Name] = policiesv1alpha1.CRDCleanupEntryStatus{Name: crd.Name, Selected: true}
+			}
+		}
+	}
+
+	if spec.DeprecatedVersions != nil {
+		matches, err := selectorMatcher(spec.DeprecatedVersions.Selector)
+		if err != nil {
+			log.Error(err, "Invalid label selector")
+			return nil, err
+		}
+		for _, crd := range crds.Items {
+			if !matches(&crd) {
+				continue
+			}
+			for _, version := range crd.Spec.Versions {
+				if version.Deprecated {
+					entry := policiesv1alpha1.CRDCleanupEntryStatus{Name: crd.Name, Version: version.Name, Selected: true}
+					selected[entryKey(&entry)] = entry
+				}
+			}
+		}
+	}
+
+	entries := make([]policiesv1alpha1.CRDCleanupEntryStatus, 0, len(selected))
+	for _, entry := range selected {
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b policiesv1alpha1.CRDCleanupEntryStatus) int {
+		return strings.Compare(entryKey(&a), entryKey(&b))
+	})
+	log.Info("Expanded selectors", "SelectedEntries", len(entries))
+	return entries, nil
+}
+
+// selectedKeys returns the keys of the selected entries as recorded in the status of the policy
+func selectedKeys(entries []policiesv1alpha1.CRDCleanupEntryStatus) []string {
+	if len(entries) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		keys = append(keys, entryKey(&entry))
+	}
+	return keys
+}
+
+// selectorMatcher returns a function that reports whether a CRD matches all fields of the selector that are set.
+// A nil selector matches all CRDs.
+func selectorMatcher(selector *policiesv1alpha1.CRDSelector) (func(*v1.CustomResourceDefinition) bool, error) {
+	if selector == nil {
+		return func(*v1.CustomResourceDefinition) bool { return true }, nil
+	}
+	labelSelector := labels.Everything()
+	if selector.LabelSelector != nil {
+		var err error
+		if labelSelector, err = metav1.LabelSelectorAsSelector(selector.LabelSelector); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(crd *v1.CustomResourceDefinition) bool {
+		if !labelSelector.Matches(labels.Set(crd.Labels)) {
+			return false
+		}
+		if selector.Group != "" {
+			if matched, _ := path.Match(selector.Group, crd.Spec.Group); !matched {
+				return false
+			}
+		}
+		for _, category := range selector.Categories {
+			if !slices.Contains(crd.Spec.Names.Categories, category) {
+				return false
+			}
+		}
+		return len(selector.Names) == 0 || matchesAny(selector.Names, crd.Name)
+	}, nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("CRD selection", func() {
+	Context("When a policy selects CRDs", func() {
+		const resourceName = "selecting-policy"
+		const selectedName = "widgets.selector.example.com"
+		const unselectedName = "gadgets.selector.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating two CRDs of the same group, only one of them labeled")
+			selected := newTestCRD("selector.example.com", "widgets", "Widget", "v1")
+			selected.Labels = map[string]string{"vendor": "acme"}
+			Expect(k8sClient.Create(ctx, selected)).To(Succeed())
+			Expect(k8sClient.Create(ctx, newTestCRD("selector.example.com", "gadgets", "Gadget", "v1"))).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					Mode: policiesv1alpha1.CleanupModeDryRun,
+					Selectors: []policiesv1alpha1.CRDSelector{{
+						Group:         "*.example.com",
+						LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"vendor": "acme"}},
+					}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			for _, name := range []string{selectedName, unselectedName} {
+				crd := &apiextensionsv1.CustomResourceDefinition{}
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name}, crd)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+			}
+		})
+
+		It("should expand the selectors into entries", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.SelectedCRDs).To(Equal([]string{selectedName}))
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Name).To(Equal(selectedName))
+			Expect(policy.Status.Entries[0].Selected).To(BeTrue())
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhasePlanned))
+		})
+	})
+
+	Context("When a policy targets deprecated versions", func() {
+		const resourceName = "deprecated-versions-policy"
+		const crdName = "relics.deprecated.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			By("creating a CRD stored in v2 whose v1 is marked deprecated")
+			crd := newTestCRD("deprecated.example.com", "relics", "Relic", "v2", "v1")
+			crd.Spec.Versions[1].Deprecated = true
+			Expect(k8sClient.Create(ctx, crd)).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					Mode: policiesv1alpha1.CleanupModeDryRun,
+					DeprecatedVersions: &policiesv1alpha1.DeprecatedVersionsTarget{
+						Selector: &policiesv1alpha1.CRDSelector{Group: "deprecated.example.com"},
+					},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, crd)).To(Succeed())
+		})
+
+		It("should queue the deprecated versions for removal", func() {
+			controllerReconciler := &CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}
+
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.SelectedCRDs).To(Equal([]string{crdName + "/v1"}))
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Version).To(Equal("v1"))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhasePlanned))
+		})
+	})
+})
+
+// Protected CRDs are named by patterns such as *.k8s.io, which envtest cannot serve without conflicts, so this
+// test runs against a fake client
+var _ = Describe("Selection of protected CRDs", func() {
+	It("should not select protected CRDs, not even with a selector matching every CRD", func() {
+		protected := newTestCRD("gateway.networking.k8s.io", "gateways", "Gateway", "v1", "v1beta1")
+		protected.Spec.Versions[1].Deprecated = true
+		relic := newTestCRD("deprecated.example.com", "relics", "Relic", "v2", "v1")
+		relic.Spec.Versions[1].Deprecated = true
+		r := &CRDCleanupPolicyReconciler{
+			Client:        fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(protected, relic).Build(),
+			Scheme:        scheme.Scheme,
+			ProtectedCRDs: []string{"*.k8s.io"},
+		}
+		policy := &policiesv1alpha1.CRDCleanupPolicy{Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+			Selectors:          []policiesv1alpha1.CRDSelector{{}},
+			DeprecatedVersions: &policiesv1alpha1.DeprecatedVersionsTarget{},
+		}}
+
+		entries, err := r.selectEntries(ctx, policy, log.FromContext(ctx))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(selectedKeys(entries)).To(Equal([]string{relic.Name, relic.Name + "/v1"}))
+	})
+})
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+const (
+	// EmergencyStopKey is the key of the emergency stop ConfigMap that stops the operator if it is "true"
+	EmergencyStopKey = "stop"
+
+	// EmergencyStopReasonKey is the key of the emergency stop ConfigMap whose value is reported as the reason
+	EmergencyStopReasonKey = "reason"
+)
+
+// EmergencyStop is the global kill switch of the operator. While it is engaged, the operator neither deletes nor
+// updates anything, but policies are still evaluated and their status is reported.
+type EmergencyStop struct {
+	// Engaged stops the operator regardless of the ConfigMap, e.g. if it was started with --emergency-stop
+	Engaged bool
+
+	// ConfigMap engages the emergency stop while its EmergencyStopKey is "true". It is watched, so the operator
+	// stops and resumes as soon as it changes. If the name is empty, no ConfigMap is used.
+	ConfigMap types.NamespacedName
+}
+
+// Reason returns why the emergency stop is engaged, or an empty string if it is not. The emergency stop is also
+// engaged if the ConfigMap exists but cannot be read, since the operator must not resume by accident.
+func (s *EmergencyStop) Reason(ctx context.Context, reader client.Reader) string {
+	switch {
+	case s == nil:
+		return ""
+	case s.Engaged:
+		return "The emergency stop of the operator is engaged"
+	case s.ConfigMap.Name == "":
+		return ""
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := reader.Get(ctx, s.ConfigMap, configMap); err != nil {
+		if errors.IsNotFound(err) {
+			return ""
+		}
+		log.FromContext(ctx).Error(err, "Failed to get the emergency stop ConfigMap", "ConfigMap", s.ConfigMap)
+		return fmt.Sprintf("The emergency stop ConfigMap %s cannot be read: %v", s.ConfigMap, err)
+	}
+	if stop, _ := strconv.ParseBool(configMap.Data[EmergencyStopKey]); !stop {
+		return ""
+	}
+	if reason := configMap.Data[EmergencyStopReasonKey]; reason != "" {
+		return fmt.Sprintf("The emergency stop is engaged by ConfigMap %s: %s", s.ConfigMap, reason)
+	}
+	return fmt.Sprintf("The emergency stop is engaged by ConfigMap %s", s.ConfigMap)
+}
+
+// isEmergencyStopConfigMap returns whether the object is the ConfigMap of the emergency stop
+func (s *EmergencyStop) isEmergencyStopConfigMap(obj client.Object) bool {
+	return s != nil && s.ConfigMap.Name != "" && client.ObjectKeyFromObject(obj) == s.ConfigMap
+}
+
+// suspendedCondition returns whether the policy is suspended or the operator is stopped as a condition
+func (r *CRDCleanupPolicyReconciler) suspendedCondition(ctx context.Context, policy cleanupPolicy) metav1.Condition {
+	condition := metav1.Condition{Type: policiesv1alpha1.ConditionTypeSuspended, ObservedGeneration: policy.GetGeneration()}
+	if reason := r.EmergencyStop.Reason(ctx, r.Client); reason != "" {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "EmergencyStop"
+		condition.Message = reason
+	} else if policy.GetSpec().Suspend {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "PolicySuspended"
+		condition.Message = "The policy is suspended"
+	} else {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "NotSuspended"
+		condition.Message = "Changes are not suspended"
+	}
+	return condition
+}
+
+// findPoliciesForEmergencyStop returns a reconcile request for every policy and cluster policy, since all of them
+// stop or resume when the emergency stop ConfigMap changes
+func (r *CRDCleanupPolicyReconciler) findPoliciesForEmergencyStop(ctx context.Context, configMap client.Object) []reconcile.Request {
+	policies := &policiesv1alpha1.CRDCleanupPolicyList{}
+	if err := r.List(ctx, policies); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list CRDCleanupPolicies for the emergency stop")
+		return nil
+	}
+	clusterPolicies := &policiesv1alpha1.ClusterCRDCleanupPolicyList{}
+	if err := r.List(ctx, clusterPolicies); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ClusterCRDCleanupPolicies for the emergency stop")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(policies.Items)+len(clusterPolicies.Items))
+	for _, policy := range policies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policy)})
+	}
+	for _, policy := range clusterPolicies.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policy)})
+	}
+	return requests
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("Suspension", func() {
+	Context("When a policy is suspended or the operator is stopped", func() {
+		const resourceName = "suspended-policy"
+		const crdName = "freezables.suspend.example.com"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+		stopNamespacedName := types.NamespacedName{Name: "kreepy-emergency-stop", Namespace: "default"}
+
+		BeforeEach(func() {
+			By("creating a CRD and a policy that deletes it")
+			Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, newTestCRD("suspend.example.com", "freezables", "Freezable", "v1")))).To(Succeed())
+			Expect(k8sClient.Create(ctx, &policiesv1alpha1.CRDCleanupPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+					CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: crdName}},
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			resource := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: stopNamespacedName.Name, Namespace: stopNamespacedName.Namespace},
+			}))).To(Succeed())
+		})
+
+		// expectSuspended reconciles the policy and checks that the CRD was evaluated, but not deleted
+		expectSuspended := func(controllerReconciler *CRDCleanupPolicyReconciler, reason string) *policiesv1alpha1.CRDCleanupPolicy {
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			Expect(policy.Status.Entries).To(HaveLen(1))
+			Expect(policy.Status.Entries[0].Phase).To(Equal(policiesv1alpha1.CRDCleanupPhaseSuspended))
+			condition := meta.FindStatusCondition(policy.Status.Conditions, policiesv1alpha1.ConditionTypeSuspended)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(reason))
+
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crdName}, crd)).To(Succeed())
+			Expect(crd.DeletionTimestamp).To(BeNil())
+			return policy
+		}
+
+		It("should not delete the CRD of a suspended policy", func() {
+			policy := &policiesv1alpha1.CRDCleanupPolicy{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, policy)).To(Succeed())
+			policy.Spec.Suspend = true
+			Expect(k8sClient.Update(ctx, policy)).To(Succeed())
+
+			expectSuspended(&CRDCleanupPolicyReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+			}, "PolicySuspended")
+		})
+
+		It("should not delete the CRD while the emergency stop ConfigMap is engaged", func() {
+			Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: stopNamespacedName.Name, Namespace: stopNamespacedName.Namespace},
+				Data:       map[string]string{EmergencyStopKey: "true", EmergencyStopReasonKey: "INC-1234"},
+			})).To(Succeed())
+
+			policy := expectSuspended(&CRDCleanupPolicyReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				EmergencyStop: &EmergencyStop{ConfigMap: stopNamespacedName},
+			}, "EmergencyStop")
+			Expect(policy.Status.Entries[0].Message).To(ContainSubstring("INC-1234"))
+		})
+	})
+})
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// quietPeriodEnded records since when the CRD of the entry has no instances and returns whether it had no
+// instances for the quiet period of the collection. The instances are counted on every reconciliation, which
+// happens at least every resyncPeriod.
+func quietPeriodEnded(entry *policiesv1alpha1.CRDCleanupEntryStatus, collection *policiesv1alpha1.UnusedCRDCollection, log logr.Logger) bool {
+	if entry.UnusedSince == nil {
+		now := metav1.Now()
+		entry.UnusedSince = &now
+		log.Info("CRD has no instances, starting quiet period", "CRD", entry.Name, "QuietPeriod", collection.QuietPeriod.Duration)
+	}
+	return !time.Now().Before(quietPeriodEnd(entry, collection))
+}
+
+// quietPeriodEnd returns the time the CRD of the entry can be deleted if no instances are created until then
+func quietPeriodEnd(entry *policiesv1alpha1.CRDCleanupEntryStatus, collection *policiesv1alpha1.UnusedCRDCollection) time.Time {
+	return entry.UnusedSince.Add(collection.QuietPeriod.Duration)
+}
+
+// nextQuietPeriodEnd returns the time until the quiet period of the next unused CRD ends, or 0 if no CRD waits for it
+func nextQuietPeriodEnd(policy cleanupPolicy) time.Duration {
+	collection := policy.GetSpec().UnusedCRDs
+	if collection == nil {
+		return 0
+	}
+
+	var next time.Duration
+	for _, entry := range policy.GetStatus().Entries {
+		if entry.Phase != policiesv1alpha1.CRDCleanupPhaseUnused || entry.UnusedSince == nil {
+			continue
+		}
+		wait := max(time.Until(quietPeriodEnd(&entry, collection)), time.Second)
+		if next == 0 || wait < next {
+			next = wait
+		}
+	}
+	return next
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protection holds the list of CRDs the operator refuses to remove unless an entry explicitly
+// acknowledges it.
+package protection
+
+import (
+	"path"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// DefaultCRDs are the glob patterns of CRD names protected by default: the CRDs of Kubernetes and its SIGs
+// and the CRDs of the operator itself.
+var DefaultCRDs = []string{
+	"*.k8s.io",
+	"*.x-k8s.io",
+	"*." + policiesv1alpha1.GroupVersion.Group,
+}
+
+// Match returns the first of the glob patterns that matches the name of the CRD, or an empty string if the
+// CRD is not protected
+func Match(patterns []string, name string) string {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return pattern
+		}
+	}
+	return ""
+}
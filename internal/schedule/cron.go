@@ -0,0 +1,198 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears bounds the search for the next activation of expressions that never match, e.g. "0 0 30 2 *"
+const maxSearchYears = 5
+
+// Cron is a parsed cron expression with the five fields minute, hour, day of month, month and day of week.
+type Cron struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	// restrictedDays is true if neither the day of month nor the day of week starts with "*", in which case
+	// a day matches if either of them matches, like in the classic cron
+	restrictedDays bool
+}
+
+// field describes the allowed values of a field of a cron expression
+type field struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	minuteField     = field{name: "minute", min: 0, max: 59}
+	hourField       = field{name: "hour", min: 0, max: 23}
+	dayOfMonthField = field{name: "day of month", min: 1, max: 31}
+	monthField      = field{name: "month", min: 1, max: 12,
+		names: []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}}
+	// Sunday is both 0 and 7
+	dayOfWeekField = field{name: "day of week", min: 0, max: 7,
+		names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}}
+)
+
+// ParseCron parses a cron expression with the five fields minute, hour, day of month, month and day of week.
+// Every field is either "*" or a comma separated list of values, ranges such as "1-5" and steps such as "*/15"
+// or "0-30/10". Months and days of week may also be given by their three letter English names.
+func ParseCron(expr string) (*Cron, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, not %d", expr, len(fields))
+	}
+
+	cron := &Cron{}
+	var err error
+	if cron.minute, err = parseField(fields[0], minuteField); err != nil {
+		return nil, err
+	}
+	if cron.hour, err = parseField(fields[1], hourField); err != nil {
+		return nil, err
+	}
+	if cron.dayOfMonth, err = parseField(fields[2], dayOfMonthField); err != nil {
+		return nil, err
+	}
+	if cron.month, err = parseField(fields[3], monthField); err != nil {
+		return nil, err
+	}
+	if cron.dayOfWeek, err = parseField(fields[4], dayOfWeekField); err != nil {
+		return nil, err
+	}
+	if cron.dayOfWeek&(1<<7) != 0 {
+		cron.dayOfWeek |= 1
+	}
+	cron.restrictedDays = !strings.HasPrefix(fields[2], "*") && !strings.HasPrefix(fields[4], "*")
+	return cron, nil
+}
+
+// parseField returns the bit set of the values of a field
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(expr, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field %q", stepExpr, f.name, expr)
+			}
+		}
+
+		first, last := f.min, f.max
+		if rangeExpr != "*" {
+			startExpr, endExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if first, err = f.value(startExpr); err != nil {
+				return 0, fmt.Errorf("%w in %s field %q", err, f.name, expr)
+			}
+			last = first
+			if isRange {
+				if last, err = f.value(endExpr); err != nil {
+					return 0, fmt.Errorf("%w in %s field %q", err, f.name, expr)
+				}
+			} else if hasStep {
+				last = f.max
+			}
+			if first > last {
+				return 0, fmt.Errorf("invalid range %q in %s field %q", rangeExpr, f.name, expr)
+			}
+		}
+		for value := first; value <= last; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number or name of the field
+func (f field) value(expr string) (int, error) {
+	for i, name := range f.names {
+		if name != "" && strings.EqualFold(name, expr) {
+			return i, nil
+		}
+	}
+	value, err := strconv.Atoi(expr)
+	if err != nil || value < f.min || value > f.max {
+		return 0, fmt.Errorf("invalid value %q, must be between %d and %d", expr, f.min, f.max)
+	}
+	return value, nil
+}
+
+// Next returns the first time after t the expression matches, in the location of t. It returns the zero time
+// if the expression does not match within the next years.
+func (c *Cron) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Year() + maxSearchYears
+
+	// Every time a field wraps around, the larger fields have to be checked again
+wrap:
+	for t.Year() <= limit {
+		for !has(c.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			if t.Month() == time.January {
+				continue wrap
+			}
+		}
+		for !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			if t.Day() == 1 {
+				continue wrap
+			}
+		}
+		for !has(c.hour, t.Hour()) {
+			// Hours are skipped in absolute time, so that the hours of a day are visited in the order they occur,
+			// including the hour repeated at the end of daylight saving time, and an hour that does not exist
+			// is skipped
+			day := t.Day()
+			t = t.Add(time.Hour - time.Duration(t.Minute())*time.Minute)
+			if t.Day() != day {
+				continue wrap
+			}
+		}
+		for !has(c.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			if t.Minute() == 0 {
+				continue wrap
+			}
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay returns whether the day of month and the day of week of t match
+func (c *Cron) matchesDay(t time.Time) bool {
+	dayOfMonth := has(c.dayOfMonth, t.Day())
+	dayOfWeek := has(c.dayOfWeek, int(t.Weekday()))
+	if c.restrictedDays {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// has returns whether the value is in the bit set
+func has(bits uint64, value int) bool {
+	return bits&(1<<value) != 0
+}
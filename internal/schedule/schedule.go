@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedule evaluates the maintenance windows of a policy, which restrict the times the operator may
+// change the cluster on behalf of the policy.
+package schedule
+
+import (
+	"fmt"
+	"time"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// Schedule is a parsed MaintenanceSchedule.
+type Schedule struct {
+	windows   []window
+	location  *time.Location
+	notBefore time.Time
+	notAfter  time.Time
+}
+
+// window is a parsed MaintenanceWindow
+type window struct {
+	cron     *Cron
+	duration time.Duration
+}
+
+// Parse parses the maintenance schedule of a policy
+func Parse(spec *policiesv1alpha1.MaintenanceSchedule) (*Schedule, error) {
+	schedule := &Schedule{location: time.UTC}
+	if spec.TimeZone != "" {
+		location, err := time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q: %w", spec.TimeZone, err)
+		}
+		schedule.location = location
+	}
+	if spec.NotBefore != nil {
+		schedule.notBefore = spec.NotBefore.Time
+	}
+	if spec.NotAfter != nil {
+		schedule.notAfter = spec.NotAfter.Time
+	}
+	for _, w := range spec.Windows {
+		cron, err := ParseCron(w.Cron)
+		if err != nil {
+			return nil, err
+		}
+		if w.Duration.Duration <= 0 {
+			return nil, fmt.Errorf("the window %q must have a positive duration", w.Cron)
+		}
+		schedule.windows = append(schedule.windows, window{cron: cron, duration: w.Duration.Duration})
+	}
+	return schedule, nil
+}
+
+// Open returns whether changes are allowed at the given time. If they are not, it also returns the time changes
+// are allowed next, or the zero time if they are never allowed again.
+func (s *Schedule) Open(now time.Time) (bool, time.Time) {
+	if !s.notAfter.IsZero() && !now.Before(s.notAfter) {
+		return false, time.Time{}
+	}
+	if !s.notBefore.IsZero() && now.Before(s.notBefore) {
+		if open, _ := s.inWindow(s.notBefore); open {
+			return false, s.notBefore
+		}
+		return false, s.nextOpening(s.notBefore)
+	}
+	if open, _ := s.inWindow(now); open {
+		return true, time.Time{}
+	}
+	return false, s.nextOpening(now)
+}
+
+// inWindow returns whether a window is open at the given time. Without windows, the schedule is always open.
+func (s *Schedule) inWindow(t time.Time) (bool, time.Time) {
+	if len(s.windows) == 0 {
+		return true, time.Time{}
+	}
+	t = t.In(s.location)
+	var next time.Time
+	for _, w := range s.windows {
+		// The window is open if it opened within its duration before t, otherwise this is its next opening
+		opening := w.cron.Next(t.Add(-w.duration))
+		if opening.IsZero() {
+			continue
+		}
+		if !opening.After(t) {
+			return true, time.Time{}
+		}
+		if next.IsZero() || opening.Before(next) {
+			next = opening
+		}
+	}
+	return false, next
+}
+
+// nextOpening returns the next time after t a window opens before NotAfter, or the zero time if there is none
+func (s *Schedule) nextOpening(t time.Time) time.Time {
+	_, next := s.inWindow(t)
+	if next.IsZero() || (!s.notAfter.IsZero() && !next.Before(s.notAfter)) {
+		return time.Time{}
+	}
+	return next
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("Schedule", func() {
+	// Friday, 17 October 2025
+	friday := time.Date(2025, time.October, 17, 12, 30, 0, 0, time.UTC)
+
+	Context("When parsing cron expressions", func() {
+		DescribeTable("should find the next activation",
+			func(expr string, after, next time.Time) {
+				cron, err := ParseCron(expr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cron.Next(after)).To(Equal(next))
+			},
+			Entry("every minute", "* * * * *", friday, friday.Add(time.Minute)),
+			Entry("every quarter hour", "*/15 * * * *", friday, friday.Add(15*time.Minute)),
+			Entry("on weekdays", "0 22 * * 1-5", friday, time.Date(2025, time.October, 17, 22, 0, 0, 0, time.UTC)),
+			Entry("on weekends by name", "0 6 * * SAT,SUN", friday, time.Date(2025, time.October, 18, 6, 0, 0, 0, time.UTC)),
+			Entry("on Sunday as 7", "0 6 * * 7", friday, time.Date(2025, time.October, 19, 6, 0, 0, 0, time.UTC)),
+			Entry("in the next year", "0 0 1 JAN *", friday, time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)),
+			Entry("on a day of month or a day of week", "0 0 1 * MON", friday, time.Date(2025, time.October, 20, 0, 0, 0, 0, time.UTC)),
+			Entry("on a leap day", "0 0 29 2 *", friday, time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)),
+		)
+
+		DescribeTable("should step through ranges",
+			func(expr string, after, next time.Time) {
+				cron, err := ParseCron(expr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cron.Next(after)).To(Equal(next))
+			},
+			Entry("with a step over a range", "10-50/20 * * * *", friday, time.Date(2025, time.October, 17, 12, 50, 0, 0, time.UTC)),
+			Entry("with a step from a start value", "5/15 * * * *", friday, time.Date(2025, time.October, 17, 12, 35, 0, 0, time.UTC)),
+			Entry("with a step and a value in a list", "0-10/5,45 * * * *", friday, time.Date(2025, time.October, 17, 12, 45, 0, 0, time.UTC)),
+			Entry("with a step over the hours", "0 */6 * * *", friday, time.Date(2025, time.October, 17, 18, 0, 0, 0, time.UTC)),
+			Entry("with a step over the months", "0 0 1 */5 *", friday, time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)),
+			Entry("with a step over the days of week ending on Sunday as 7", "0 0 * * 5-7/2", friday, time.Date(2025, time.October, 19, 0, 0, 0, 0, time.UTC)),
+		)
+
+		DescribeTable("should match a day if either the day of month or the day of week matches, unless one is *",
+			func(expr string, after, next time.Time) {
+				cron, err := ParseCron(expr)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cron.Next(after)).To(Equal(next))
+			},
+			Entry("on the 13th or on Fridays", "0 0 13 * FRI", friday, time.Date(2025, time.October, 24, 0, 0, 0, 0, time.UTC)),
+			Entry("on the first week or on Mondays", "0 0 1-7 * MON", friday, time.Date(2025, time.October, 20, 0, 0, 0, 0, time.UTC)),
+			Entry("on the 18th or on Mondays", "0 0 18 * MON", friday, time.Date(2025, time.October, 18, 0, 0, 0, 0, time.UTC)),
+			Entry("on Mondays that are the 1st, 11th, 21st or 31st", "0 0 */10 * MON", friday, time.Date(2025, time.December, 1, 0, 0, 0, 0, time.UTC)),
+			Entry("on the 20th if it is any day of week", "0 0 20 * *", friday, time.Date(2025, time.October, 20, 0, 0, 0, 0, time.UTC)),
+		)
+
+		DescribeTable("should follow daylight saving time changes in the location of the time",
+			func(expr string, after, next time.Time) {
+				berlin, err := time.LoadLocation("Europe/Berlin")
+				Expect(err).NotTo(HaveOccurred())
+				cron, err := ParseCron(expr)
+				Expect(err).NotTo(HaveOccurred())
+				activation := cron.Next(after.In(berlin))
+				Expect(activation).To(BeTemporally("==", next))
+				Expect(activation.Location()).To(Equal(berlin))
+			},
+			// On 29 March 2026 the clocks move from 02:00 CET to 03:00 CEST
+			Entry("skipping a time that does not exist", "30 2 * * *",
+				time.Date(2026, time.March, 28, 22, 0, 0, 0, time.UTC), time.Date(2026, time.March, 30, 0, 30, 0, 0, time.UTC)),
+			Entry("with the hour after the skipped hour", "0 * * * *",
+				time.Date(2026, time.March, 29, 0, 30, 0, 0, time.UTC), time.Date(2026, time.March, 29, 1, 0, 0, 0, time.UTC)),
+			Entry("at a time after the change", "0 3 * * *",
+				time.Date(2026, time.March, 28, 22, 0, 0, 0, time.UTC), time.Date(2026, time.March, 29, 1, 0, 0, 0, time.UTC)),
+			// On 25 October 2026 the clocks move from 03:00 CEST back to 02:00 CET
+			Entry("at the first occurrence of a repeated time", "30 2 * * *",
+				time.Date(2026, time.October, 24, 21, 0, 0, 0, time.UTC), time.Date(2026, time.October, 25, 0, 30, 0, 0, time.UTC)),
+			Entry("on the next day after the second occurrence of a repeated time", "30 2 * * *",
+				time.Date(2026, time.October, 25, 1, 30, 0, 0, time.UTC), time.Date(2026, time.October, 26, 1, 30, 0, 0, time.UTC)),
+			Entry("with every hour of the longer day", "0 * * * *",
+				time.Date(2026, time.October, 25, 0, 30, 0, 0, time.UTC), time.Date(2026, time.October, 25, 1, 0, 0, 0, time.UTC)),
+			Entry("at midnight after the longer day", "0 0 * * *",
+				time.Date(2026, time.October, 25, 1, 30, 0, 0, time.UTC), time.Date(2026, time.October, 25, 23, 0, 0, 0, time.UTC)),
+		)
+
+		It("should return the zero time for expressions that never match", func() {
+			cron, err := ParseCron("0 0 30 2 *")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cron.Next(friday).IsZero()).To(BeTrue())
+		})
+
+		DescribeTable("should reject invalid expressions",
+			func(expr string) {
+				_, err := ParseCron(expr)
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("with too few fields", "0 22 * *"),
+			Entry("with a value out of range", "0 24 * * *"),
+			Entry("with an inverted range", "0 22-20 * * *"),
+			Entry("with a zero step", "*/0 * * * *"),
+			Entry("with an unknown name", "0 0 * * FUN"),
+		)
+	})
+
+	Context("When evaluating maintenance windows", func() {
+		weekdayEvenings := policiesv1alpha1.MaintenanceWindow{Cron: "0 22 * * 1-5", Duration: metav1.Duration{Duration: 2 * time.Hour}}
+
+		It("should be open within a window and closed outside of it", func() {
+			schedule, err := Parse(&policiesv1alpha1.MaintenanceSchedule{Windows: []policiesv1alpha1.MaintenanceWindow{weekdayEvenings}})
+			Expect(err).NotTo(HaveOccurred())
+
+			open, next := schedule.Open(friday)
+			Expect(open).To(BeFalse())
+			Expect(next).To(Equal(time.Date(2025, time.October, 17, 22, 0, 0, 0, time.UTC)))
+
+			open, _ = schedule.Open(time.Date(2025, time.October, 17, 23, 59, 0, 0, time.UTC))
+			Expect(open).To(BeTrue())
+
+			open, next = schedule.Open(time.Date(2025, time.October, 18, 0, 0, 0, 0, time.UTC))
+			Expect(open).To(BeFalse())
+			Expect(next).To(Equal(time.Date(2025, time.October, 20, 22, 0, 0, 0, time.UTC)))
+		})
+
+		It("should evaluate the windows in the time zone of the schedule", func() {
+			schedule, err := Parse(&policiesv1alpha1.MaintenanceSchedule{
+				TimeZone: "Europe/Berlin",
+				Windows:  []policiesv1alpha1.MaintenanceWindow{weekdayEvenings},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			open, next := schedule.Open(friday)
+			Expect(open).To(BeFalse())
+			Expect(next).To(BeTemporally("==", time.Date(2025, time.October, 17, 20, 0, 0, 0, time.UTC)))
+		})
+
+		It("should only open between notBefore and notAfter", func() {
+			notBefore := metav1.NewTime(time.Date(2025, time.October, 20, 23, 0, 0, 0, time.UTC))
+			notAfter := metav1.NewTime(time.Date(2025, time.October, 21, 12, 0, 0, 0, time.UTC))
+			schedule, err := Parse(&policiesv1alpha1.MaintenanceSchedule{
+				Windows:   []policiesv1alpha1.MaintenanceWindow{weekdayEvenings},
+				NotBefore: &notBefore,
+				NotAfter:  &notAfter,
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("opening at notBefore, since a window is open then")
+			open, next := schedule.Open(friday)
+			Expect(open).To(BeFalse())
+			Expect(next).To(Equal(notBefore.Time))
+
+			By("skipping windows that open after notAfter")
+			open, _ = schedule.Open(time.Date(2025, time.October, 20, 23, 30, 0, 0, time.UTC))
+			Expect(open).To(BeTrue())
+			open, next = schedule.Open(time.Date(2025, time.October, 21, 0, 0, 0, 0, time.UTC))
+			Expect(open).To(BeFalse())
+			Expect(next.IsZero()).To(BeTrue())
+
+			open, next = schedule.Open(notAfter.Time)
+			Expect(open).To(BeFalse())
+			Expect(next.IsZero()).To(BeTrue())
+		})
+
+		It("should always be open without windows", func() {
+			schedule, err := Parse(&policiesv1alpha1.MaintenanceSchedule{})
+			Expect(err).NotTo(HaveOccurred())
+			open, _ := schedule.Open(friday)
+			Expect(open).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// clustercrdcleanuppolicylog logs the admission of ClusterCRDCleanupPolicies
+var clustercrdcleanuppolicylog = logf.Log.WithName("clustercrdcleanuppolicy-resource")
+
+// SetupClusterCRDCleanupPolicyWebhookWithManager registers the webhook for ClusterCRDCleanupPolicy in the manager.
+// Entries for CRDs matching one of the protected patterns are rejected unless they acknowledge the protection.
+func SetupClusterCRDCleanupPolicyWebhookWithManager(mgr ctrl.Manager, protectedCRDs []string) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&policiesv1alpha1.ClusterCRDCleanupPolicy{}).
+		WithDefaulter(&ClusterCRDCleanupPolicyCustomDefaulter{}).
+		WithValidator(&ClusterCRDCleanupPolicyCustomValidator{Client: mgr.GetClient(), ProtectedCRDs: protectedCRDs}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-policies-kreepy-kubecrew-de-v1alpha1-clustercrdcleanuppolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=policies.kreepy.kubecrew.de,resources=clustercrdcleanuppolicies,verbs=create;update,versions=v1alpha1,name=mclustercrdcleanuppolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterCRDCleanupPolicyCustomDefaulter records the user that creates or updates a ClusterCRDCleanupPolicy,
+// so that the operator only deletes the CRDs this user may delete.
+type ClusterCRDCleanupPolicyCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &ClusterCRDCleanupPolicyCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter and records the requester of the ClusterCRDCleanupPolicy.
+func (d *ClusterCRDCleanupPolicyCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*policiesv1alpha1.ClusterCRDCleanupPolicy)
+	if !ok {
+		return fmt.Errorf("expected a ClusterCRDCleanupPolicy object but got %T", obj)
+	}
+	clustercrdcleanuppolicylog.Info("Defaulting for ClusterCRDCleanupPolicy", "name", policy.GetName())
+
+	return recordRequester(ctx, policy)
+}
+
+// +kubebuilder:webhook:path=/validate-policies-kreepy-kubecrew-de-v1alpha1-clustercrdcleanuppolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=policies.kreepy.kubecrew.de,resources=clustercrdcleanuppolicies,verbs=create;update,versions=v1alpha1,name=vclustercrdcleanuppolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// ClusterCRDCleanupPolicyCustomValidator rejects ClusterCRDCleanupPolicies with malformed or duplicate entries, versions that do not exist on
+// the CRD, entries that remove all versions of a CRD, entries that target a CRD of another policy and entries
+// for protected CRDs that do not acknowledge the protection.
+type ClusterCRDCleanupPolicyCustomValidator struct {
+	Client client.Reader
+
+	// ProtectedCRDs are glob patterns of CRD names that entries may only target if they acknowledge the protection
+	ProtectedCRDs []string
+}
+
+var _ webhook.CustomValidator = &ClusterCRDCleanupPolicyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator and validates the entries of a new ClusterCRDCleanupPolicy.
+func (v *ClusterCRDCleanupPolicyCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*policiesv1alpha1.ClusterCRDCleanupPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterCRDCleanupPolicy object but got %T", obj)
+	}
+	clustercrdcleanuppolicylog.Info("Validation for ClusterCRDCleanupPolicy upon creation", "name", policy.GetName())
+
+	return v.validate(ctx, policy, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator and validates the entries of an updated ClusterCRDCleanupPolicy.
+func (v *ClusterCRDCleanupPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	policy, ok := newObj.(*policiesv1alpha1.ClusterCRDCleanupPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterCRDCleanupPolicy object for the newObj but got %T", newObj)
+	}
+	oldPolicy, ok := oldObj.(*policiesv1alpha1.ClusterCRDCleanupPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a ClusterCRDCleanupPolicy object for the oldObj but got %T", oldObj)
+	}
+	clustercrdcleanuppolicylog.Info("Validation for ClusterCRDCleanupPolicy upon update", "name", policy.GetName())
+
+	return v.validate(ctx, policy, &oldPolicy.Spec)
+}
+
+// ValidateDelete implements webhook.CustomValidator. A ClusterCRDCleanupPolicy can always be deleted.
+func (v *ClusterCRDCleanupPolicyCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the spec of the policy against the spec it replaces, if any
+func (v *ClusterCRDCleanupPolicyCustomValidator) validate(ctx context.Context, policy *policiesv1alpha1.ClusterCRDCleanupPolicy, oldSpec *policiesv1alpha1.CRDCleanupPolicySpec) (admission.Warnings, error) {
+	if policy.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	warnings, allErrs, err := (&policyValidator{Reader: v.Client, protectedCRDs: v.ProtectedCRDs}).validate(ctx, policy, &policy.Spec, oldSpec)
+	if err != nil {
+		return nil, err
+	}
+	if len(allErrs) == 0 {
+		// Keep an audit trail of who acknowledged the removal of protected CRDs
+		req, _ := admission.RequestFromContext(ctx)
+		for _, warning := range warnings {
+			clustercrdcleanuppolicylog.Info("Protected CRD acknowledged", "name", policy.GetName(), "namespace", policy.GetNamespace(), "user", req.UserInfo.Username, "warning", warning)
+		}
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(policiesv1alpha1.GroupVersion.WithKind("ClusterCRDCleanupPolicy").GroupKind(), policy.Name, allErrs)
+}
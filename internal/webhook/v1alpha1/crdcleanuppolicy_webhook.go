@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+// crdcleanuppolicylog logs the admission of CRDCleanupPolicies
+var crdcleanuppolicylog = logf.Log.WithName("crdcleanuppolicy-resource")
+
+// SetupCRDCleanupPolicyWebhookWithManager registers the webhook for CRDCleanupPolicy in the manager.
+// Entries for CRDs matching one of the protected patterns are rejected unless they acknowledge the protection.
+func SetupCRDCleanupPolicyWebhookWithManager(mgr ctrl.Manager, protectedCRDs []string) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&policiesv1alpha1.CRDCleanupPolicy{}).
+		WithDefaulter(&CRDCleanupPolicyCustomDefaulter{}).
+		WithValidator(&CRDCleanupPolicyCustomValidator{Client: mgr.GetClient(), ProtectedCRDs: protectedCRDs}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-policies-kreepy-kubecrew-de-v1alpha1-crdcleanuppolicy,mutating=true,failurePolicy=fail,sideEffects=None,groups=policies.kreepy.kubecrew.de,resources=crdcleanuppolicies,verbs=create;update,versions=v1alpha1,name=mcrdcleanuppolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CRDCleanupPolicyCustomDefaulter records the user that creates or updates a CRDCleanupPolicy,
+// so that the operator only deletes the CRDs this user may delete.
+type CRDCleanupPolicyCustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &CRDCleanupPolicyCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter and records the requester of the CRDCleanupPolicy.
+func (d *CRDCleanupPolicyCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	policy, ok := obj.(*policiesv1alpha1.CRDCleanupPolicy)
+	if !ok {
+		return fmt.Errorf("expected a CRDCleanupPolicy object but got %T", obj)
+	}
+	crdcleanuppolicylog.Info("Defaulting for CRDCleanupPolicy", "name", policy.GetName())
+
+	return recordRequester(ctx, policy)
+}
+
+// recordRequester records the user of the admission request in the annotations of the policy. Any value set by
+// the user is overwritten, so the annotations always name the user that created or last updated the policy.
+func recordRequester(ctx context.Context, policy client.Object) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	annotations := policy.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[policiesv1alpha1.RequestedByAnnotation] = req.UserInfo.Username
+	annotations[policiesv1alpha1.RequestedByGroupsAnnotation] = strings.Join(req.UserInfo.Groups, ",")
+	policy.SetAnnotations(annotations)
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-policies-kreepy-kubecrew-de-v1alpha1-crdcleanuppolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=policies.kreepy.kubecrew.de,resources=crdcleanuppolicies,verbs=create;update,versions=v1alpha1,name=vcrdcleanuppolicy-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// CRDCleanupPolicyCustomValidator rejects CRDCleanupPolicies with malformed or duplicate entries, versions that do not exist on
+// the CRD, entries that remove all versions of a CRD, entries that target a CRD of another policy and entries
+// for protected CRDs that do not acknowledge the protection.
+type CRDCleanupPolicyCustomValidator struct {
+	Client client.Reader
+
+	// ProtectedCRDs are glob patterns of CRD names that entries may only target if they acknowledge the protection
+	ProtectedCRDs []string
+}
+
+var _ webhook.CustomValidator = &CRDCleanupPolicyCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator and validates the entries of a new CRDCleanupPolicy.
+func (v *CRDCleanupPolicyCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*policiesv1alpha1.CRDCleanupPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a CRDCleanupPolicy object but got %T", obj)
+	}
+	crdcleanuppolicylog.Info("Validation for CRDCleanupPolicy upon creation", "name", policy.GetName())
+
+	return v.validate(ctx, policy, nil)
+}
+
+// ValidateUpdate implements webhook.CustomValidator and validates the entries of an updated CRDCleanupPolicy.
+func (v *CRDCleanupPolicyCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	policy, ok := newObj.(*policiesv1alpha1.CRDCleanupPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a CRDCleanupPolicy object for the newObj but got %T", newObj)
+	}
+	oldPolicy, ok := oldObj.(*policiesv1alpha1.CRDCleanupPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a CRDCleanupPolicy object for the oldObj but got %T", oldObj)
+	}
+	crdcleanuppolicylog.Info("Validation for CRDCleanupPolicy upon update", "name", policy.GetName())
+
+	return v.validate(ctx, policy, &oldPolicy.Spec)
+}
+
+// ValidateDelete implements webhook.CustomValidator. A CRDCleanupPolicy can always be deleted.
+func (v *CRDCleanupPolicyCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the spec of the policy against the spec it replaces, if any
+func (v *CRDCleanupPolicyCustomValidator) validate(ctx context.Context, policy *policiesv1alpha1.CRDCleanupPolicy, oldSpec *policiesv1alpha1.CRDCleanupPolicySpec) (admission.Warnings, error) {
+	if policy.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	warnings, allErrs, err := (&policyValidator{Reader: v.Client, protectedCRDs: v.ProtectedCRDs}).validate(ctx, policy, &policy.Spec, oldSpec)
+	if err != nil {
+		return nil, err
+	}
+	if len(allErrs) == 0 {
+		// Keep an audit trail of who acknowledged the removal of protected CRDs
+		req, _ := admission.RequestFromContext(ctx)
+		for _, warning := range warnings {
+			crdcleanuppolicylog.Info("Protected CRD acknowledged", "name", policy.GetName(), "namespace", policy.GetNamespace(), "user", req.UserInfo.Username, "warning", warning)
+		}
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(policiesv1alpha1.GroupVersion.WithKind("CRDCleanupPolicy").GroupKind(), policy.Name, allErrs)
+}
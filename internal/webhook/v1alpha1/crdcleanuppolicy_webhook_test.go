@@ -0,0 +1,185 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+)
+
+var _ = Describe("CRDCleanupPolicy Webhook", func() {
+	var (
+		obj       *policiesv1alpha1.CRDCleanupPolicy
+		defaulter CRDCleanupPolicyCustomDefaulter
+		ctx       context.Context
+	)
+
+	BeforeEach(func() {
+		obj = &policiesv1alpha1.CRDCleanupPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "crdcleanuppolicy-sample",
+				Namespace: "default",
+				Annotations: map[string]string{
+					policiesv1alpha1.RequestedByAnnotation: "someone-else",
+				},
+			},
+		}
+		defaulter = CRDCleanupPolicyCustomDefaulter{}
+		ctx = admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{
+					Username: "jane",
+					Groups:   []string{"platform", "system:authenticated"},
+				},
+			},
+		})
+	})
+
+	Context("When creating or updating CRDCleanupPolicy under Defaulting Webhook", func() {
+		It("Should record the requesting user and overwrite any value set by the user", func() {
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Annotations).To(HaveKeyWithValue(policiesv1alpha1.RequestedByAnnotation, "jane"))
+			Expect(obj.Annotations).To(HaveKeyWithValue(policiesv1alpha1.RequestedByGroupsAnnotation, "platform,system:authenticated"))
+		})
+
+		It("Should fail without an admission request", func() {
+			Expect(defaulter.Default(context.Background(), obj)).NotTo(Succeed())
+		})
+	})
+
+	Context("When creating or updating CRDCleanupPolicy under Validating Webhook", func() {
+		var validator CRDCleanupPolicyCustomValidator
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(policiesv1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+
+			validator = CRDCleanupPolicyCustomValidator{
+				ProtectedCRDs: []string{"*.k8s.io"},
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&apiextensionsv1.CustomResourceDefinition{
+						ObjectMeta: metav1.ObjectMeta{Name: "multisamples.example.com"},
+						Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+							Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+								{Name: "v1", Served: true, Storage: true},
+								{Name: "v2", Served: true},
+							},
+						},
+					},
+					&policiesv1alpha1.CRDCleanupPolicy{
+						ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "team-a"},
+						Spec: policiesv1alpha1.CRDCleanupPolicySpec{
+							CRDsVersions: []policiesv1alpha1.CRDCleanupVersion{{Name: "taken.example.com"}},
+						},
+					},
+				).Build(),
+			}
+		})
+
+		It("Should admit valid entries", func() {
+			obj.Spec.CRDsVersions = []policiesv1alpha1.CRDCleanupVersion{
+				{Name: "samples.example.com"},
+				{Name: "multisamples.example.com", Version: "v1"},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		DescribeTable("Should deny invalid entries",
+			func(entries []policiesv1alpha1.CRDCleanupVersion, field string) {
+				obj.Spec.CRDsVersions = entries
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(apierrors.IsInvalid(err)).To(BeTrue())
+				Expect(err.Error()).To(ContainSubstring(field))
+			},
+			Entry("with a malformed name", []policiesv1alpha1.CRDCleanupVersion{{Name: "Samples"}}, "spec.crdsversions[0].name"),
+			Entry("with a duplicate entry", []policiesv1alpha1.CRDCleanupVersion{
+				{Name: "samples.example.com"},
+				{Name: "samples.example.com"},
+			}, "spec.crdsversions[1]"),
+			Entry("with a version the CRD does not have", []policiesv1alpha1.CRDCleanupVersion{
+				{Name: "multisamples.example.com", Version: "v3"},
+			}, "spec.crdsversions[0].version"),
+			Entry("removing all versions of a CRD", []policiesv1alpha1.CRDCleanupVersion{
+				{Name: "multisamples.example.com", Version: "v1"},
+				{Name: "multisamples.example.com", Version: "v2"},
+			}, "remove all versions"),
+			Entry("targeting the CRD of another policy", []policiesv1alpha1.CRDCleanupVersion{
+				{Name: "taken.example.com"},
+			}, "CRDCleanupPolicy team-a/other"),
+			Entry("targeting a protected CRD", []policiesv1alpha1.CRDCleanupVersion{
+				{Name: "gateways.gateway.networking.k8s.io"},
+			}, "protected by pattern *.k8s.io"),
+		)
+
+		It("Should deny selectors that select every CRD", func() {
+			obj.Spec.Selectors = []policiesv1alpha1.CRDSelector{{}}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.selectors[0]"))
+		})
+
+		It("Should deny schedules with invalid cron expressions and time zones", func() {
+			obj.Spec.Schedule = &policiesv1alpha1.MaintenanceSchedule{
+				TimeZone: "Mars/Olympus_Mons",
+				Windows: []policiesv1alpha1.MaintenanceWindow{
+					{Cron: "0 22 * * 1-5", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+					{Cron: "0 25 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(apierrors.IsInvalid(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("spec.schedule.timeZone"))
+			Expect(err.Error()).To(ContainSubstring("spec.schedule.windows[1].cron"))
+			Expect(err.Error()).NotTo(ContainSubstring("spec.schedule.windows[0]"))
+		})
+
+		It("Should admit protected CRDs with an acknowledgement and warn about them", func() {
+			obj.Spec.CRDsVersions = []policiesv1alpha1.CRDCleanupVersion{{
+				Name:                 "gateways.gateway.networking.k8s.io",
+				AcknowledgeProtected: &policiesv1alpha1.ProtectionAcknowledgement{Reason: "Replaced by the vendor CRD"},
+			}}
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("Replaced by the vendor CRD")))
+		})
+
+		It("Should admit updates that keep entries whose versions were already removed", func() {
+			obj.Spec.CRDsVersions = []policiesv1alpha1.CRDCleanupVersion{
+				{Name: "multisamples.example.com", Version: "v0"},
+			}
+			oldObj := obj.DeepCopy()
+			obj.Spec.CRDsVersions = append(obj.Spec.CRDsVersions, policiesv1alpha1.CRDCleanupVersion{Name: "samples.example.com"})
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
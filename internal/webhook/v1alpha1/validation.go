@@ -0,0 +1,295 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	policiesv1alpha1 "github.com/kubecrew/kreepy/api/v1alpha1"
+	"github.com/kubecrew/kreepy/internal/protection"
+	"github.com/kubecrew/kreepy/internal/schedule"
+)
+
+// entryKey identifies an entry of a policy by the CRD and version it targets
+type entryKey struct {
+	name    string
+	version string
+}
+
+// policyValidator validates the spec of CRDCleanupPolicies and ClusterCRDCleanupPolicies against the CRDs in the
+// cluster and the other policies
+type policyValidator struct {
+	client.Reader
+
+	// protectedCRDs are glob patterns of CRD names that entries may only target if they acknowledge the protection
+	protectedCRDs []string
+}
+
+// validate returns the errors of the given spec and a warning for every acknowledged protected CRD. Checks against
+// the cluster are only done for the CRDs of entries that are not part of the old spec, so policies whose versions
+// were already removed by the operator can still be updated.
+func (v *policyValidator) validate(ctx context.Context, policy client.Object, spec, oldSpec *policiesv1alpha1.CRDCleanupPolicySpec) (admission.Warnings, field.ErrorList, error) {
+	var warnings admission.Warnings
+	var allErrs field.ErrorList
+	entriesPath := field.NewPath("spec", "crdsversions")
+
+	existing := map[entryKey]bool{}
+	if oldSpec != nil {
+		for _, entry := range oldSpec.CRDsVersions {
+			existing[entryKey{entry.Name, entry.Version}] = true
+		}
+	}
+
+	seen := map[entryKey]bool{}
+	changedCRDs := map[string]bool{}
+	for i, entry := range spec.CRDsVersions {
+		entryPath := entriesPath.Index(i)
+		allErrs = append(allErrs, validateEntryNames(entry, entryPath)...)
+
+		if pattern := protection.Match(v.protectedCRDs, entry.Name); pattern != "" {
+			if entry.AcknowledgeProtected == nil {
+				allErrs = append(allErrs, field.Forbidden(entryPath.Child("name"), fmt.Sprintf("CRD %s is protected by pattern %s, set acknowledgeProtected to remove it", entry.Name, pattern)))
+			} else {
+				warnings = append(warnings, fmt.Sprintf("%s acknowledges the removal of protected CRD %s: %s", entryPath, entry.Name, entry.AcknowledgeProtected.Reason))
+			}
+		}
+
+		key := entryKey{entry.Name, entry.Version}
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(entryPath, fmt.Sprintf("%s %s", entry.Name, entry.Version)))
+		}
+		seen[key] = true
+		if !existing[key] {
+			changedCRDs[entry.Name] = true
+		}
+	}
+	selectorsPath := field.NewPath("spec", "selectors")
+	for i := range spec.Selectors {
+		allErrs = append(allErrs, validateSelector(&spec.Selectors[i], selectorsPath.Index(i), true)...)
+	}
+	if spec.DeprecatedVersions != nil && spec.DeprecatedVersions.Selector != nil {
+		allErrs = append(allErrs, validateSelector(spec.DeprecatedVersions.Selector, field.NewPath("spec", "deprecatedVersions", "selector"), false)...)
+	}
+	if collection := spec.UnusedCRDs; collection != nil {
+		collectionPath := field.NewPath("spec", "unusedCRDs")
+		if collection.QuietPeriod.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(collectionPath.Child("quietPeriod"), collection.QuietPeriod.Duration.String(), "must not be negative"))
+		}
+		if collection.Selector != nil {
+			allErrs = append(allErrs, validateSelector(collection.Selector, collectionPath.Child("selector"), true)...)
+		}
+	}
+	if spec.Schedule != nil {
+		allErrs = append(allErrs, validateSchedule(spec.Schedule, field.NewPath("spec", "schedule"))...)
+	}
+	if len(allErrs) > 0 {
+		return warnings, allErrs, nil
+	}
+
+	names := make([]string, 0, len(changedCRDs))
+	for name := range changedCRDs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		crdErrs, err := v.validateCRD(ctx, name, spec, entriesPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		allErrs = append(allErrs, crdErrs...)
+	}
+
+	conflictErrs, err := v.validateConflicts(ctx, policy, spec, changedCRDs, entriesPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return warnings, append(allErrs, conflictErrs...), nil
+}
+
+// validateEntryNames checks that the entry names a CRD by <plural>.<group> and that its versions are valid version names
+func validateEntryNames(entry policiesv1alpha1.CRDCleanupVersion, entryPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if msgs := validation.IsDNS1123Subdomain(entry.Name); len(msgs) > 0 {
+		allErrs = append(allErrs, field.Invalid(entryPath.Child("name"), entry.Name, strings.Join(msgs, ", ")))
+	} else if !strings.Contains(entry.Name, ".") {
+		allErrs = append(allErrs, field.Invalid(entryPath.Child("name"), entry.Name, "must be of the form <plural>.<group>"))
+	}
+	for _, version := range []struct{ child, value string }{{"version", entry.Version}, {"storageSuccessor", entry.StorageSuccessor}} {
+		if version.value == "" {
+			continue
+		}
+		if msgs := validation.IsDNS1035Label(version.value); len(msgs) > 0 {
+			allErrs = append(allErrs, field.Invalid(entryPath.Child(version.child), version.value, strings.Join(msgs, ", ")))
+		}
+	}
+	if entry.StorageSuccessor != "" && entry.StorageSuccessor == entry.Version {
+		allErrs = append(allErrs, field.Invalid(entryPath.Child("storageSuccessor"), entry.StorageSuccessor, "must differ from the removed version"))
+	}
+	return allErrs
+}
+
+// validateSelector checks that the patterns of the selector are valid and, if restricting is set, that the
+// selector does not select every CRD
+func validateSelector(selector *policiesv1alpha1.CRDSelector, selectorPath *field.Path, restricting bool) field.ErrorList {
+	if restricting && selector.LabelSelector == nil && selector.Group == "" && len(selector.Categories) == 0 && len(selector.Names) == 0 {
+		return field.ErrorList{field.Required(selectorPath, "at least one of labelSelector, group, categories or names must be set")}
+	}
+
+	var allErrs field.ErrorList
+	if selector.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector.LabelSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(selectorPath.Child("labelSelector"), selector.LabelSelector, err.Error()))
+		}
+	}
+	if _, err := path.Match(selector.Group, ""); err != nil {
+		allErrs = append(allErrs, field.Invalid(selectorPath.Child("group"), selector.Group, err.Error()))
+	}
+	for i, name := range selector.Names {
+		if _, err := path.Match(name, ""); err != nil {
+			allErrs = append(allErrs, field.Invalid(selectorPath.Child("names").Index(i), name, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// validateSchedule checks that the time zone and the cron expressions of the schedule can be parsed, that every
+// window has a duration and that the schedule does not end before it starts
+func validateSchedule(spec *policiesv1alpha1.MaintenanceSchedule, schedulePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.TimeZone != "" {
+		if _, err := time.LoadLocation(spec.TimeZone); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("timeZone"), spec.TimeZone, "must be an IANA time zone name"))
+		}
+	}
+	for i, window := range spec.Windows {
+		windowPath := schedulePath.Child("windows").Index(i)
+		if _, err := schedule.ParseCron(window.Cron); err != nil {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("cron"), window.Cron, err.Error()))
+		}
+		if window.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(windowPath.Child("duration"), window.Duration.Duration.String(), "must be positive"))
+		}
+	}
+	if spec.NotBefore != nil && spec.NotAfter != nil && !spec.NotBefore.Before(spec.NotAfter) {
+		allErrs = append(allErrs, field.Invalid(schedulePath.Child("notAfter"), spec.NotAfter.Format(time.RFC3339), "must be after notBefore"))
+	}
+	return allErrs
+}
+
+// validateCRD checks the entries of a CRD against the CRD in the cluster, if it exists. Every version must exist,
+// and the entries must not remove all versions of the CRD.
+func (v *policyValidator) validateCRD(ctx context.Context, name string, spec *policiesv1alpha1.CRDCleanupPolicySpec, entriesPath *field.Path) (field.ErrorList, error) {
+	crd := &v1.CustomResourceDefinition{}
+	if err := v.Get(ctx, types.NamespacedName{Name: name}, crd); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	versions := map[string]bool{}
+	for _, version := range crd.Spec.Versions {
+		versions[version.Name] = true
+	}
+
+	var allErrs field.ErrorList
+	removed := map[string]bool{}
+	for i, entry := range spec.CRDsVersions {
+		if entry.Name != name || entry.Version == "" {
+			continue
+		}
+		if !versions[entry.Version] {
+			allErrs = append(allErrs, field.NotFound(entriesPath.Index(i).Child("version"), entry.Version))
+			continue
+		}
+		if entry.StorageSuccessor != "" && !versions[entry.StorageSuccessor] {
+			allErrs = append(allErrs, field.NotFound(entriesPath.Index(i).Child("storageSuccessor"), entry.StorageSuccessor))
+		}
+		removed[entry.Version] = true
+	}
+	if len(removed) > 0 && len(removed) == len(versions) {
+		allErrs = append(allErrs, field.Forbidden(entriesPath, fmt.Sprintf("the entries remove all versions of CRD %s, target the CRD without a version to delete it", name)))
+	}
+	return allErrs, nil
+}
+
+// validateConflicts checks that no other live policy targets the given CRDs. A ClusterCRDCleanupPolicy may target
+// the CRDs of CRDCleanupPolicies, since it takes precedence over them.
+func (v *policyValidator) validateConflicts(ctx context.Context, policy client.Object, spec *policiesv1alpha1.CRDCleanupPolicySpec, crds map[string]bool, entriesPath *field.Path) (field.ErrorList, error) {
+	if len(crds) == 0 {
+		return nil, nil
+	}
+	_, isClusterPolicy := policy.(*policiesv1alpha1.ClusterCRDCleanupPolicy)
+
+	owners := map[string]string{}
+	clusterPolicies := &policiesv1alpha1.ClusterCRDCleanupPolicyList{}
+	if err := v.List(ctx, clusterPolicies); err != nil {
+		return nil, err
+	}
+	for i := range clusterPolicies.Items {
+		other := &clusterPolicies.Items[i]
+		if isClusterPolicy && other.Name == policy.GetName() || other.DeletionTimestamp != nil {
+			continue
+		}
+		for _, entry := range other.Spec.CRDsVersions {
+			owners[entry.Name] = "ClusterCRDCleanupPolicy " + other.Name
+		}
+	}
+
+	if !isClusterPolicy {
+		policies := &policiesv1alpha1.CRDCleanupPolicyList{}
+		if err := v.List(ctx, policies); err != nil {
+			return nil, err
+		}
+		for i := range policies.Items {
+			other := &policies.Items[i]
+			if other.Namespace == policy.GetNamespace() && other.Name == policy.GetName() || other.DeletionTimestamp != nil {
+				continue
+			}
+			for _, entry := range other.Spec.CRDsVersions {
+				if _, ok := owners[entry.Name]; !ok {
+					owners[entry.Name] = "CRDCleanupPolicy " + other.Namespace + "/" + other.Name
+				}
+			}
+		}
+	}
+
+	var allErrs field.ErrorList
+	for i, entry := range spec.CRDsVersions {
+		if !crds[entry.Name] {
+			continue
+		}
+		if owner, ok := owners[entry.Name]; ok {
+			allErrs = append(allErrs, field.Forbidden(entriesPath.Index(i).Child("name"), fmt.Sprintf("CRD %s is already targeted by %s", entry.Name, owner)))
+		}
+	}
+	return allErrs, nil
+}